@@ -7,7 +7,9 @@ import (
 	"github.com/fletaio/fleta/process/admin"
 	"github.com/fletaio/fleta/process/formulator"
 	"github.com/fletaio/fleta/process/gateway"
+	"github.com/fletaio/fleta/process/nft"
 	"github.com/fletaio/fleta/process/payment"
+	"github.com/fletaio/fleta/process/token"
 	"github.com/fletaio/fleta/process/vault"
 )
 
@@ -129,6 +131,32 @@ func (app *FletaApp) InitGenesis(ctw *types.ContextWrapper) error {
 			return err
 		}
 	}
+	if p, err := app.pm.ProcessByName("fleta.token"); err != nil {
+		return err
+	} else if tp, is := p.(*token.Token); !is {
+		return types.ErrNotExistProcess
+	} else {
+		if err := tp.InitPolicy(ctw,
+			&token.Policy{
+				CreationCost: amount.NewCoinAmount(10, 0),
+			},
+		); err != nil {
+			return err
+		}
+	}
+	if p, err := app.pm.ProcessByName("fleta.nft"); err != nil {
+		return err
+	} else if np, is := p.(*nft.NFT); !is {
+		return types.ErrNotExistProcess
+	} else {
+		if err := np.InitPolicy(ctw,
+			&nft.Policy{
+				CreationCost: amount.NewCoinAmount(10, 0),
+			},
+		); err != nil {
+			return err
+		}
+	}
 	if p, err := app.pm.ProcessByName("fleta.vault"); err != nil {
 		return err
 	} else if sp, is := p.(*vault.Vault); !is {
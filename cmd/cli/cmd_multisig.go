@@ -0,0 +1,112 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+func multisigCommand(pHostURL *string) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "multisig",
+		Short: "collects signatures of the multi account transaction",
+	}
+	cmd.AddCommand(&cobra.Command{
+		Use:   "create [from] [to] [amount]",
+		Short: "creates a partially signed transfer of the multi account",
+		Args:  cobra.MinimumNArgs(3),
+		Run: func(cmd *cobra.Command, args []string) {
+			res, err := DoRequest((*pHostURL), "bank.createMultiSigTransfer", []interface{}{args[0], args[1], args[2]})
+			if err != nil {
+				fmt.Println("error :", err)
+			} else {
+				fmt.Println(res)
+			}
+		},
+	})
+	cmd.AddCommand(&cobra.Command{
+		Use:   "get [tx_hash]",
+		Short: "returns the partially signed transaction with its signers",
+		Args:  cobra.MinimumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			res, err := DoRequest((*pHostURL), "bank.multiSigTx", []interface{}{args[0]})
+			if err != nil {
+				fmt.Println("error :", err)
+			} else {
+				bs, err := json.MarshalIndent(res, "", "\t")
+				if err != nil {
+					fmt.Println("error :", err)
+				} else {
+					fmt.Println(string(bs))
+				}
+			}
+		},
+	})
+	cmd.AddCommand(&cobra.Command{
+		Use:   "sign [tx_hash] [name] [password]",
+		Short: "adds the signature of the key to the partially signed transaction",
+		Args:  cobra.MinimumNArgs(3),
+		Run: func(cmd *cobra.Command, args []string) {
+			res, err := DoRequest((*pHostURL), "bank.signMultiSigTx", []interface{}{args[0], args[1], args[2]})
+			if err != nil {
+				fmt.Println("error :", err)
+			} else {
+				fmt.Println(res)
+			}
+		},
+	})
+	cmd.AddCommand(&cobra.Command{
+		Use:   "add-signature [tx_hash] [signature]",
+		Short: "adds the signature made offline to the partially signed transaction",
+		Args:  cobra.MinimumNArgs(2),
+		Run: func(cmd *cobra.Command, args []string) {
+			res, err := DoRequest((*pHostURL), "bank.addMultiSigSignature", []interface{}{args[0], args[1]})
+			if err != nil {
+				fmt.Println("error :", err)
+			} else {
+				fmt.Println(res)
+			}
+		},
+	})
+	cmd.AddCommand(&cobra.Command{
+		Use:   "export [tx_hash]",
+		Short: "returns the hex of the partially signed transaction to pass other signers",
+		Args:  cobra.MinimumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			res, err := DoRequest((*pHostURL), "bank.exportMultiSigTx", []interface{}{args[0]})
+			if err != nil {
+				fmt.Println("error :", err)
+			} else {
+				fmt.Println(res)
+			}
+		},
+	})
+	cmd.AddCommand(&cobra.Command{
+		Use:   "import [hex]",
+		Short: "imports the partially signed transaction exported from other signer",
+		Args:  cobra.MinimumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			res, err := DoRequest((*pHostURL), "bank.importMultiSigTx", []interface{}{args[0]})
+			if err != nil {
+				fmt.Println("error :", err)
+			} else {
+				fmt.Println(res)
+			}
+		},
+	})
+	cmd.AddCommand(&cobra.Command{
+		Use:   "broadcast [tx_hash]",
+		Short: "sends the transaction when it has enough signatures",
+		Args:  cobra.MinimumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			res, err := DoRequest((*pHostURL), "bank.broadcastMultiSigTx", []interface{}{args[0]})
+			if err != nil {
+				fmt.Println("error :", err)
+			} else {
+				fmt.Println(res)
+			}
+		},
+	})
+	return cmd
+}
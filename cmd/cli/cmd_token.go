@@ -0,0 +1,81 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+func tokenCommand(pHostURL *string) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "token",
+		Short: "manages tokens and token balances",
+	}
+	cmd.AddCommand(&cobra.Command{
+		Use:   "list",
+		Short: "returns created tokens",
+		Run: func(cmd *cobra.Command, args []string) {
+			res, err := DoRequest((*pHostURL), "token.tokens", []interface{}{})
+			if err != nil {
+				fmt.Println("error :", err)
+			} else {
+				bs, err := json.MarshalIndent(res, "", "\t")
+				if err != nil {
+					fmt.Println("error :", err)
+				} else {
+					fmt.Println(string(bs))
+				}
+			}
+		},
+	})
+	cmd.AddCommand(&cobra.Command{
+		Use:   "info [symbol]",
+		Short: "returns information of the token",
+		Args:  cobra.MinimumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			res, err := DoRequest((*pHostURL), "token.tokenInfo", []interface{}{args[0]})
+			if err != nil {
+				fmt.Println("error :", err)
+			} else {
+				bs, err := json.MarshalIndent(res, "", "\t")
+				if err != nil {
+					fmt.Println("error :", err)
+				} else {
+					fmt.Println(string(bs))
+				}
+			}
+		},
+	})
+	cmd.AddCommand(&cobra.Command{
+		Use:   "balance [address] [symbol]",
+		Short: "returns the token balance of the address",
+		Args:  cobra.MinimumNArgs(2),
+		Run: func(cmd *cobra.Command, args []string) {
+			res, err := DoRequest((*pHostURL), "token.balance", []interface{}{args[0], args[1]})
+			if err != nil {
+				fmt.Println("error :", err)
+			} else {
+				fmt.Println(res)
+			}
+		},
+	})
+	cmd.AddCommand(&cobra.Command{
+		Use:   "send [from] [symbol] [to] [amount] (password)",
+		Short: "sends the amount of the token",
+		Args:  cobra.MinimumNArgs(4),
+		Run: func(cmd *cobra.Command, args []string) {
+			var Password string
+			if len(args) > 4 {
+				Password = args[4]
+			}
+			res, err := DoRequest((*pHostURL), "bank.sendToken", []interface{}{args[0], args[1], args[2], args[3], Password})
+			if err != nil {
+				fmt.Println("error :", err)
+			} else {
+				fmt.Println(res)
+			}
+		},
+	})
+	return cmd
+}
@@ -11,6 +11,8 @@ func main() {
 	rootCmd.AddCommand(keyCommand(&hostURL))
 	rootCmd.AddCommand(accountCommand(&hostURL))
 	rootCmd.AddCommand(txCommand(&hostURL))
+	rootCmd.AddCommand(multisigCommand(&hostURL))
+	rootCmd.AddCommand(tokenCommand(&hostURL))
 	rootCmd.AddCommand(chainCommand(&hostURL))
 	rootCmd.Execute()
 }
@@ -7,6 +7,7 @@ import (
 	"os/signal"
 	"strconv"
 	"syscall"
+	"time"
 
 	"github.com/fletaio/fleta/core/types"
 
@@ -25,7 +26,10 @@ import (
 	"github.com/fletaio/fleta/process/admin"
 	"github.com/fletaio/fleta/process/formulator"
 	"github.com/fletaio/fleta/process/gateway"
+	"github.com/fletaio/fleta/process/governance"
+	"github.com/fletaio/fleta/process/nft"
 	"github.com/fletaio/fleta/process/payment"
+	"github.com/fletaio/fleta/process/token"
 	"github.com/fletaio/fleta/process/vault"
 	"github.com/fletaio/fleta/service/apiserver"
 )
@@ -43,6 +47,9 @@ type Config struct {
 	RLogHost       string
 	RLogPath       string
 	UseRLog        bool
+	LeaseFile      string
+	LeaseHolderID  string
+	LeaseTTL       int
 }
 
 func main() {
@@ -169,6 +176,9 @@ func main() {
 	cn.MustAddProcess(formulator.NewFormulator(3))
 	cn.MustAddProcess(gateway.NewGateway(4))
 	cn.MustAddProcess(payment.NewPayment(5))
+	cn.MustAddProcess(token.NewToken(6))
+	cn.MustAddProcess(nft.NewNFT(7))
+	cn.MustAddProcess(governance.NewGovernance(8))
 	as := apiserver.NewAPIServer()
 	cn.MustAddService(as)
 	if err := cn.Init(); err != nil {
@@ -192,15 +202,34 @@ func main() {
 		panic(err)
 	}
 
-	fr := pof.NewFormulatorNode(&pof.FormulatorConfig{
+	var lease *pof.FileLease
+	if len(cfg.LeaseFile) > 0 {
+		if len(cfg.LeaseHolderID) == 0 {
+			panic("LeaseHolderID should be set to use LeaseFile")
+		}
+		if cfg.LeaseTTL <= 0 {
+			cfg.LeaseTTL = 9
+		}
+		lease = pof.NewFileLease(cfg.LeaseFile, cfg.LeaseHolderID, time.Duration(cfg.LeaseTTL)*time.Second)
+	}
+
+	frcfg := &pof.FormulatorConfig{
 		Formulator:              common.MustParseAddress(cfg.Formulator),
 		MaxTransactionsPerBlock: 10000,
-	}, frkey, ndkey, NetAddressMap, SeedNodeMap, cs, cfg.StoreRoot+"/peer")
+	}
+	if lease != nil {
+		frcfg.Lease = lease
+	}
+	fr := pof.NewFormulatorNode(frcfg, frkey, ndkey, NetAddressMap, SeedNodeMap, cs, cfg.StoreRoot+"/peer")
 	if err := fr.Init(); err != nil {
 		panic(err)
 	}
 	cm.RemoveAll()
 	cm.Add("formulator", fr)
+	if lease != nil {
+		cm.Add("lease", lease)
+		go lease.Run()
+	}
 
 	go fr.Run(":" + strconv.Itoa(cfg.Port))
 	go as.Run(":" + strconv.Itoa(cfg.APIPort))
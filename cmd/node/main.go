@@ -7,6 +7,7 @@ import (
 	"os/signal"
 	"strconv"
 	"syscall"
+	"time"
 
 	"github.com/fletaio/fleta/core/pile"
 
@@ -24,9 +25,13 @@ import (
 	"github.com/fletaio/fleta/process/admin"
 	"github.com/fletaio/fleta/process/formulator"
 	"github.com/fletaio/fleta/process/gateway"
+	"github.com/fletaio/fleta/process/governance"
+	"github.com/fletaio/fleta/process/nft"
 	"github.com/fletaio/fleta/process/payment"
+	"github.com/fletaio/fleta/process/token"
 	"github.com/fletaio/fleta/process/vault"
 	"github.com/fletaio/fleta/service/apiserver"
+	gatewaysv "github.com/fletaio/fleta/service/gateway"
 	"github.com/fletaio/fleta/service/p2p"
 )
 
@@ -41,6 +46,21 @@ type Config struct {
 	RLogHost     string
 	RLogPath     string
 	UseRLog      bool
+	Relayer      RelayerConfig
+}
+
+// RelayerConfig is a configuration of the gateway relayer which is enabled when Platform is set
+// DepositFile and PayoutFile are read by the file watcher and Peers are api server urls of other relayers of the committee
+type RelayerConfig struct {
+	Platform       string
+	GatewayAddress string
+	KeyHex         string
+	Peers          []string
+	PeerTimeout    int
+	IsCoordinator  bool
+	DepositFile    string
+	PayoutFile     string
+	PollInterval   int
 }
 
 func main() {
@@ -156,8 +176,17 @@ func main() {
 	cn.MustAddProcess(formulator.NewFormulator(3))
 	cn.MustAddProcess(gateway.NewGateway(4))
 	cn.MustAddProcess(payment.NewPayment(5))
+	cn.MustAddProcess(token.NewToken(6))
+	cn.MustAddProcess(nft.NewNFT(7))
+	cn.MustAddProcess(governance.NewGovernance(8))
 	as := apiserver.NewAPIServer()
 	cn.MustAddService(as)
+
+	var rs *gatewaysv.Relayer
+	if len(cfg.Relayer.Platform) > 0 {
+		rs = newRelayer(&cfg.Relayer, cfg.StoreRoot)
+		cn.MustAddService(rs)
+	}
 	if err := cn.Init(); err != nil {
 		panic(err)
 	}
@@ -185,9 +214,51 @@ func main() {
 	}
 	cm.RemoveAll()
 	cm.Add("node", nd)
+	if rs != nil {
+		rs.SetNode(nd)
+		cm.Add("relayer", rs)
+		go rs.Run()
+	}
 
 	go nd.Run(":" + strconv.Itoa(cfg.Port))
 	go as.Run(":" + strconv.Itoa(cfg.APIPort))
 
 	cm.Wait()
 }
+
+func newRelayer(rcfg *RelayerConfig, StoreRoot string) *gatewaysv.Relayer {
+	if len(rcfg.DepositFile) == 0 {
+		panic("DepositFile should be set to use Relayer")
+	}
+	if len(rcfg.PayoutFile) == 0 {
+		panic("PayoutFile should be set to use Relayer")
+	}
+	bs, err := hex.DecodeString(rcfg.KeyHex)
+	if err != nil {
+		panic(err)
+	}
+	Key, err := key.NewMemoryKeyFromBytes(bs)
+	if err != nil {
+		panic(err)
+	}
+	GatewayAddress, err := common.ParseAddress(rcfg.GatewayAddress)
+	if err != nil {
+		panic(err)
+	}
+	Peers := []gatewaysv.RelayerPeer{}
+	for _, v := range rcfg.Peers {
+		Peers = append(Peers, gatewaysv.NewJRPCPeer(v, time.Duration(rcfg.PeerTimeout)*time.Second))
+	}
+	rst, err := backend.Create("buntdb", StoreRoot+"/relayer")
+	if err != nil {
+		panic(err)
+	}
+	return gatewaysv.NewRelayer(&gatewaysv.RelayerConfig{
+		Platform:       rcfg.Platform,
+		GatewayAddress: GatewayAddress,
+		Key:            Key,
+		Peers:          Peers,
+		IsCoordinator:  rcfg.IsCoordinator,
+		PollInterval:   time.Duration(rcfg.PollInterval) * time.Second,
+	}, rst, gatewaysv.NewFileWatcher(rcfg.DepositFile, rcfg.PayoutFile))
+}
@@ -26,7 +26,10 @@ import (
 	"github.com/fletaio/fleta/process/admin"
 	"github.com/fletaio/fleta/process/formulator"
 	"github.com/fletaio/fleta/process/gateway"
+	"github.com/fletaio/fleta/process/governance"
+	"github.com/fletaio/fleta/process/nft"
 	"github.com/fletaio/fleta/process/payment"
+	"github.com/fletaio/fleta/process/token"
 	"github.com/fletaio/fleta/process/vault"
 	"github.com/fletaio/fleta/service/apiserver"
 	"github.com/fletaio/fleta/service/p2p"
@@ -160,6 +163,9 @@ func main() {
 	cn.MustAddProcess(fp)
 	cn.MustAddProcess(gateway.NewGateway(4))
 	cn.MustAddProcess(payment.NewPayment(5))
+	cn.MustAddProcess(token.NewToken(6))
+	cn.MustAddProcess(nft.NewNFT(7))
+	cn.MustAddProcess(governance.NewGovernance(8))
 	as := apiserver.NewAPIServer()
 	cn.MustAddService(as)
 	keyStore, err := backend.Create("buntdb", cfg.StoreRoot+"/keystore")
@@ -3,6 +3,7 @@ package pof
 import (
 	"bytes"
 	"sync"
+	"sync/atomic"
 
 	"github.com/fletaio/fleta/common"
 	"github.com/fletaio/fleta/core/chain"
@@ -13,6 +14,8 @@ import (
 
 // Consensus implements the proof of formulator algorithm
 type Consensus struct {
+	blockInterval int64 // accessed atomically and kept first for 64-bit alignment
+	roundTimeout  int64 // accessed atomically
 	sync.Mutex
 	*chain.ConsensusBase
 	cn                     *chain.Chain
@@ -21,6 +24,7 @@ type Consensus struct {
 	blocksBySameFormulator uint32
 	observerKeyMap         *types.PublicHashBoolMap
 	rt                     *RankTable
+	policyProvider         ConsensusPolicyProvider
 }
 
 // NewConsensus returns a Consensus
@@ -31,6 +35,8 @@ func NewConsensus(MaxBlocksPerFormulator uint32, ObserverKeys []common.PublicHas
 	}
 	cs := &Consensus{
 		maxBlocksPerFormulator: MaxBlocksPerFormulator,
+		blockInterval:          int64(DefaultBlockInterval),
+		roundTimeout:           int64(DefaultRoundTimeout),
 		observerKeyMap:         ObserverKeyMap,
 		rt:                     NewRankTable(),
 	}
@@ -42,6 +48,13 @@ func (cs *Consensus) Init(cn *chain.Chain, ct chain.Committer) error {
 	cs.cn = cn
 	cs.ct = ct
 
+	for _, p := range cn.Processes() {
+		if v, is := p.(ConsensusPolicyProvider); is {
+			cs.policyProvider = v
+			break
+		}
+	}
+
 	if vs, err := cn.ServiceByName("fleta.apiserver"); err != nil {
 		//ignore when not loaded
 	} else if v, is := vs.(*apiserver.APIServer); !is {
@@ -55,6 +68,21 @@ func (cs *Consensus) Init(cn *chain.Chain, ct chain.Committer) error {
 			list := cs.rt.Candidates()
 			return list, nil
 		})
+
+		js, err := v.JRPC("chain")
+		if err != nil {
+			return err
+		}
+		js.Set("getFinality", func(ID interface{}, arg *apiserver.Argument) (interface{}, error) {
+			if arg.Len() != 1 {
+				return nil, apiserver.ErrInvalidArgument
+			}
+			height, err := arg.Uint32(0)
+			if err != nil {
+				return nil, err
+			}
+			return cs.Finality(height)
+		})
 	}
 
 	return nil
@@ -85,9 +113,10 @@ func (cs *Consensus) OnLoadChain(loader types.LoaderWrapper) error {
 	if v, err := dec.DecodeUint32(); err != nil {
 		return err
 	} else {
-		if cs.maxBlocksPerFormulator != v {
+		if v == 0 {
 			return ErrInvalidMaxBlocksPerFormulator
 		}
+		atomic.StoreUint32(&cs.maxBlocksPerFormulator, v)
 	}
 	ObserverKeyMap := types.NewPublicHashBoolMap()
 	if err := dec.Decode(&ObserverKeyMap); err != nil {
@@ -116,11 +145,16 @@ func (cs *Consensus) OnLoadChain(loader types.LoaderWrapper) error {
 	if err := dec.Decode(&cs.rt); err != nil {
 		return err
 	}
+	cs.applyConsensusPolicy(loader)
 	return nil
 }
 
 // ValidateSignature called when required to validate signatures
 func (cs *Consensus) ValidateSignature(bh *types.Header, sigs []common.Signature) error {
+	if len(sigs) < 2 {
+		return ErrInvalidSignatureCount
+	}
+
 	TimeoutCount, err := cs.DecodeConsensusData(bh.ConsensusData)
 	if err != nil {
 		return err
@@ -144,20 +178,13 @@ func (cs *Consensus) ValidateSignature(bh *types.Header, sigs []common.Signature
 		return ErrInvalidTopSignature
 	}
 
-	if len(sigs) != cs.observerKeyMap.Len()/2+2 {
-		return ErrInvalidSignatureCount
-	}
-	KeyMap := map[common.PublicHash]bool{}
-	cs.observerKeyMap.EachAll(func(pubhash common.PublicHash, value bool) bool {
-		KeyMap[pubhash] = true
-		return true
-	})
 	bs := types.BlockSign{
 		HeaderHash:         encoding.Hash(bh),
 		GeneratorSignature: sigs[0],
 	}
 	ObserverSignatures := sigs[1:]
-	if err := common.ValidateSignaturesMajority(encoding.Hash(bs), ObserverSignatures, KeyMap); err != nil {
+	scheme := ObserverSignatureSchemeByVersion(bh.Version)
+	if err := scheme.Validate(encoding.Hash(bs), ObserverSignatures, cs.ObserverKeys()); err != nil {
 		return err
 	}
 	return nil
@@ -170,6 +197,8 @@ func (cs *Consensus) OnSaveData(b *types.Block, ctw *types.ContextWrapper) error
 
 	HeaderHash := encoding.Hash(b.Header)
 
+	cs.applyConsensusPolicy(ctw)
+
 	TimeoutCount, err := cs.DecodeConsensusData(b.Header.ConsensusData)
 	if err != nil {
 		return err
@@ -181,7 +210,7 @@ func (cs *Consensus) OnSaveData(b *types.Block, ctw *types.ContextWrapper) error
 		cs.blocksBySameFormulator = 0
 	}
 	cs.blocksBySameFormulator++
-	if cs.blocksBySameFormulator >= cs.maxBlocksPerFormulator {
+	if cs.blocksBySameFormulator >= cs.MaxBlocksPerFormulator() {
 		cs.rt.forwardTop(HeaderHash)
 		cs.blocksBySameFormulator = 0
 	}
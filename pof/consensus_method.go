@@ -17,6 +17,12 @@ func (cs *Consensus) Candidates() []*Rank {
 	return cs.rt.Candidates()
 }
 
+// PackObserverSignatures packs observer signatures of the block sign by the scheme of the header version
+func (cs *Consensus) PackObserverSignatures(Version uint16, bs *types.BlockSign, SigMap map[common.PublicHash]common.Signature) ([]common.Signature, error) {
+	scheme := ObserverSignatureSchemeByVersion(Version)
+	return scheme.Pack(encoding.Hash(bs), SigMap, cs.ObserverKeys())
+}
+
 func (cs *Consensus) updateFormulatorList(ctw *types.ContextWrapper) error {
 	var inErr error
 	phase := cs.rt.smallestPhase() + 2
@@ -92,7 +98,7 @@ func (cs *Consensus) encodeConsensusData(TimeoutCount uint32) ([]byte, error) {
 func (cs *Consensus) buildSaveData() ([]byte, error) {
 	var buffer bytes.Buffer
 	enc := encoding.NewEncoder(&buffer)
-	if err := enc.EncodeUint32(cs.maxBlocksPerFormulator); err != nil {
+	if err := enc.EncodeUint32(cs.MaxBlocksPerFormulator()); err != nil {
 		return nil, err
 	}
 	if err := enc.Encode(cs.observerKeyMap); err != nil {
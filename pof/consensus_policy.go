@@ -0,0 +1,58 @@
+package pof
+
+import (
+	"sync/atomic"
+	"time"
+
+	"github.com/fletaio/fleta/core/types"
+)
+
+// default consensus parameters
+const (
+	DefaultBlockInterval = 500 * time.Millisecond
+	DefaultRoundTimeout  = 3 * time.Second
+)
+
+// ConsensusPolicyProvider provides consensus parameters that are updated at runtime
+type ConsensusPolicyProvider interface {
+	ConsensusParameters(loader types.Loader) (MaxBlocksPerFormulator uint32, BlockInterval time.Duration, RoundTimeout time.Duration, has bool)
+}
+
+// MaxBlocksPerFormulator returns the maximum number of blocks that a formulator generates in a row
+func (cs *Consensus) MaxBlocksPerFormulator() uint32 {
+	return atomic.LoadUint32(&cs.maxBlocksPerFormulator)
+}
+
+// BlockInterval returns the target interval between blocks
+func (cs *Consensus) BlockInterval() time.Duration {
+	return time.Duration(atomic.LoadInt64(&cs.blockInterval))
+}
+
+// RoundTimeout returns the timeout of a vote round
+func (cs *Consensus) RoundTimeout() time.Duration {
+	return time.Duration(atomic.LoadInt64(&cs.roundTimeout))
+}
+
+// BlockGenTimeout returns the time to collect transactions for a block, which is two fifths of the block interval
+func (cs *Consensus) BlockGenTimeout() time.Duration {
+	return cs.BlockInterval() * 2 / 5
+}
+
+func (cs *Consensus) applyConsensusPolicy(loader types.Loader) {
+	if cs.policyProvider == nil {
+		return
+	}
+	MaxBlocksPerFormulator, BlockInterval, RoundTimeout, has := cs.policyProvider.ConsensusParameters(loader)
+	if !has {
+		return
+	}
+	if MaxBlocksPerFormulator > 0 {
+		atomic.StoreUint32(&cs.maxBlocksPerFormulator, MaxBlocksPerFormulator)
+	}
+	if BlockInterval > 0 {
+		atomic.StoreInt64(&cs.blockInterval, int64(BlockInterval))
+	}
+	if RoundTimeout > 0 {
+		atomic.StoreInt64(&cs.roundTimeout, int64(RoundTimeout))
+	}
+}
@@ -20,4 +20,8 @@ var (
 	ErrAlreadyVoted                  = errors.New("already voted")
 	ErrNotExistObserverPeer          = errors.New("not exist observer peer")
 	ErrNotExistFormulatorPeer        = errors.New("not exist formulator peer")
+	ErrNotFinalizedHeight            = errors.New("not finalized height")
+	ErrInvalidFinalityCertificate    = errors.New("invalid finality certificate")
+	ErrExistSignatureSchemeVersion   = errors.New("exist signature scheme version")
+	ErrLeaseLocked                   = errors.New("lease locked")
 )
@@ -0,0 +1,194 @@
+package pof
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+
+	"github.com/fletaio/fleta/common"
+	"github.com/fletaio/fleta/common/hash"
+	"github.com/fletaio/fleta/core/types"
+	"github.com/fletaio/fleta/encoding"
+)
+
+// FinalityCertificate proves that the block of the height is finalized by the majority of observers
+// it carries the encoded header so the verifier can check the height without trusting the certificate
+type FinalityCertificate struct {
+	ChainID             uint8
+	Version             uint16
+	Height              uint32
+	HeaderHash          hash.Hash256
+	Header              []byte
+	GeneratorPublicHash common.PublicHash
+	GeneratorSignature  common.Signature
+	ObserverSignatures  []common.Signature
+}
+
+// NewFinalityCertificate returns a FinalityCertificate of the block
+func NewFinalityCertificate(b *types.Block) (*FinalityCertificate, error) {
+	if len(b.Signatures) < 2 {
+		return nil, ErrInvalidSignatureCount
+	}
+	data, err := encoding.Marshal(b.Header)
+	if err != nil {
+		return nil, err
+	}
+	HeaderHash := encoding.Hash(b.Header)
+	pubkey, err := common.RecoverPubkey(HeaderHash, b.Signatures[0])
+	if err != nil {
+		return nil, err
+	}
+	fc := &FinalityCertificate{
+		ChainID:             b.Header.ChainID,
+		Version:             b.Header.Version,
+		Height:              b.Header.Height,
+		HeaderHash:          HeaderHash,
+		Header:              data,
+		GeneratorPublicHash: common.NewPublicHash(pubkey),
+		GeneratorSignature:  b.Signatures[0],
+		ObserverSignatures:  make([]common.Signature, 0, len(b.Signatures)-1),
+	}
+	for _, sig := range b.Signatures[1:] {
+		fc.ObserverSignatures = append(fc.ObserverSignatures, sig.Clone())
+	}
+	return fc, nil
+}
+
+// SignedHash returns the hash that observers signed
+func (fc *FinalityCertificate) SignedHash() hash.Hash256 {
+	bs := types.BlockSign{
+		HeaderHash:         fc.HeaderHash,
+		GeneratorSignature: fc.GeneratorSignature,
+	}
+	return encoding.Hash(bs)
+}
+
+// VerifyFinalityCertificate validates the certificate using the chain id and the observer key list only
+// the header is decoded and hashed again, so the height, the chain id and the version are bound to the signatures
+func VerifyFinalityCertificate(fc *FinalityCertificate, ChainID uint8, ObserverKeys []common.PublicHash) error {
+	bh := types.Header{}
+	if err := encoding.Unmarshal(fc.Header, &bh); err != nil {
+		return err
+	}
+	if encoding.Hash(bh) != fc.HeaderHash {
+		return ErrInvalidFinalityCertificate
+	}
+	if bh.ChainID != ChainID || bh.ChainID != fc.ChainID {
+		return ErrInvalidFinalityCertificate
+	}
+	if bh.Height != fc.Height || bh.Version != fc.Version {
+		return ErrInvalidFinalityCertificate
+	}
+
+	pubkey, err := common.RecoverPubkey(fc.HeaderHash, fc.GeneratorSignature)
+	if err != nil {
+		return err
+	}
+	if common.NewPublicHash(pubkey) != fc.GeneratorPublicHash {
+		return ErrInvalidTopSignature
+	}
+
+	scheme := ObserverSignatureSchemeByVersion(bh.Version)
+	if err := scheme.Validate(fc.SignedHash(), fc.ObserverSignatures, ObserverKeys); err != nil {
+		return err
+	}
+	return nil
+}
+
+// ObserverKeys returns the public hashes of observers
+func (cs *Consensus) ObserverKeys() []common.PublicHash {
+	keys := make([]common.PublicHash, 0, cs.observerKeyMap.Len())
+	cs.observerKeyMap.EachAll(func(pubhash common.PublicHash, value bool) bool {
+		keys = append(keys, pubhash.Clone())
+		return true
+	})
+	return keys
+}
+
+// Finality returns the finality certificate of the height
+func (cs *Consensus) Finality(height uint32) (*FinalityCertificate, error) {
+	provider := cs.cn.Provider()
+	if height == 0 || height > provider.Height() {
+		return nil, ErrNotFinalizedHeight
+	}
+	b, err := provider.Block(height)
+	if err != nil {
+		return nil, err
+	}
+	fc, err := NewFinalityCertificate(b)
+	if err != nil {
+		return nil, err
+	}
+	if err := VerifyFinalityCertificate(fc, provider.ChainID(), cs.ObserverKeys()); err != nil {
+		return nil, err
+	}
+	return fc, nil
+}
+
+// MarshalJSON is a marshaler function
+func (fc *FinalityCertificate) MarshalJSON() ([]byte, error) {
+	var buffer bytes.Buffer
+	buffer.WriteString(`{`)
+	buffer.WriteString(`"chain_id":`)
+	if bs, err := json.Marshal(fc.ChainID); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"version":`)
+	if bs, err := json.Marshal(fc.Version); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"height":`)
+	if bs, err := json.Marshal(fc.Height); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"header_hash":`)
+	if bs, err := fc.HeaderHash.MarshalJSON(); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"header":`)
+	buffer.WriteString(`"`)
+	buffer.WriteString(hex.EncodeToString(fc.Header))
+	buffer.WriteString(`"`)
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"generator_public_hash":`)
+	if bs, err := fc.GeneratorPublicHash.MarshalJSON(); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"generator_signature":`)
+	if bs, err := fc.GeneratorSignature.MarshalJSON(); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"observer_signatures":`)
+	buffer.WriteString(`[`)
+	for i, sig := range fc.ObserverSignatures {
+		if i > 0 {
+			buffer.WriteString(`,`)
+		}
+		if bs, err := sig.MarshalJSON(); err != nil {
+			return nil, err
+		} else {
+			buffer.Write(bs)
+		}
+	}
+	buffer.WriteString(`]`)
+	buffer.WriteString(`}`)
+	return buffer.Bytes(), nil
+}
@@ -0,0 +1,208 @@
+package pof
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/fletaio/fleta/encoding"
+)
+
+// FormulatorLease decides which one of formulator processes sharing the generator key responds to block requests
+type FormulatorLease interface {
+	IsHolder() bool
+}
+
+type leaseRecord struct {
+	HolderID   string
+	ExpireTime int64
+}
+
+// FileLease is a FormulatorLease using a lease file in the storage shared by active and standby processes
+type FileLease struct {
+	sync.Mutex
+	path       string
+	holderID   string
+	ttl        time.Duration
+	margin     time.Duration
+	expireTime int64
+	isClose    bool
+}
+
+// NewFileLease returns a FileLease
+func NewFileLease(Path string, HolderID string, TTL time.Duration) *FileLease {
+	fl := &FileLease{
+		path:     Path,
+		holderID: HolderID,
+		ttl:      TTL,
+		margin:   TTL / 3,
+	}
+	return fl
+}
+
+// IsHolder returns true when the lease is held by this process with the safety margin
+func (fl *FileLease) IsHolder() bool {
+	fl.Lock()
+	defer fl.Unlock()
+
+	return time.Now().UnixNano() < fl.expireTime-int64(fl.margin)
+}
+
+// Close stops renewing and releases the lease
+func (fl *FileLease) Close() {
+	fl.Lock()
+	defer fl.Unlock()
+
+	if fl.isClose {
+		return
+	}
+	fl.isClose = true
+	if fl.expireTime > 0 {
+		// the record expires by itself when the lock is busy
+		fl.withLock(func() error {
+			rec, err := fl.readRecord()
+			if err != nil {
+				return err
+			}
+			if rec != nil && rec.HolderID == fl.holderID {
+				return os.Remove(fl.path)
+			}
+			return nil
+		})
+	}
+	fl.expireTime = 0
+}
+
+// Run tries to acquire and renew the lease until closed
+func (fl *FileLease) Run() {
+	for {
+		fl.Lock()
+		if fl.isClose {
+			fl.Unlock()
+			return
+		}
+		fl.renew()
+		fl.Unlock()
+
+		time.Sleep(fl.margin)
+	}
+}
+
+// renew keeps the current expire time when the lease file cannot be updated because the record still holds it until then
+func (fl *FileLease) renew() {
+	if err := fl.tryAcquire(); err != nil {
+		if time.Now().UnixNano() >= fl.expireTime {
+			fl.expireTime = 0
+		}
+	}
+}
+
+func (fl *FileLease) tryAcquire() error {
+	return fl.withLock(func() error {
+		now := time.Now().UnixNano()
+		rec, err := fl.readRecord()
+		if err != nil {
+			return err
+		}
+		if rec != nil && rec.HolderID != fl.holderID && now < rec.ExpireTime {
+			fl.expireTime = 0
+			return nil
+		}
+		if rec != nil && rec.HolderID == fl.holderID && fl.expireTime == 0 && now < rec.ExpireTime {
+			// the previous run of this holder may still be generating blocks
+			return nil
+		}
+		ExpireTime := now + int64(fl.ttl)
+		if err := fl.writeRecord(&leaseRecord{
+			HolderID:   fl.holderID,
+			ExpireTime: ExpireTime,
+		}); err != nil {
+			return err
+		}
+		fl.expireTime = ExpireTime
+		return nil
+	})
+}
+
+func (fl *FileLease) withLock(fn func() error) error {
+	lockPath := fl.path + ".lock"
+	token := []byte(fl.holderID + "/" + strconv.FormatInt(time.Now().UnixNano(), 10))
+	f, err := os.OpenFile(lockPath, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0600)
+	if err != nil {
+		if os.IsExist(err) {
+			fl.breakStaleLock(lockPath)
+			return ErrLeaseLocked
+		}
+		return err
+	}
+	_, err = f.Write(token)
+	f.Close()
+	if err != nil {
+		os.Remove(lockPath)
+		return err
+	}
+	defer fl.releaseLock(lockPath, token)
+
+	return fn()
+}
+
+// breakStaleLock removes the lock file left by a crashed process
+// the lock file is moved away by rename so only one process can take it and a fresh lock moved by mistake is linked back
+func (fl *FileLease) breakStaleLock(lockPath string) {
+	if st, err := os.Stat(lockPath); err != nil || time.Since(st.ModTime()) <= fl.ttl {
+		return
+	}
+	stalePath := lockPath + "." + fl.holderID + ".stale"
+	if err := os.Rename(lockPath, stalePath); err != nil {
+		return
+	}
+	if st, err := os.Stat(stalePath); err == nil && time.Since(st.ModTime()) <= fl.ttl {
+		os.Link(stalePath, lockPath)
+	}
+	os.Remove(stalePath)
+}
+
+// releaseLock removes the lock file only when it is still the one created by this call
+func (fl *FileLease) releaseLock(lockPath string, token []byte) {
+	if bs, err := ioutil.ReadFile(lockPath); err == nil && bytes.Equal(bs, token) {
+		os.Remove(lockPath)
+	}
+}
+
+func (fl *FileLease) readRecord() (*leaseRecord, error) {
+	bs, err := ioutil.ReadFile(fl.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	rec := &leaseRecord{}
+	if err := encoding.Unmarshal(bs, &rec); err != nil {
+		return nil, err
+	}
+	return rec, nil
+}
+
+func (fl *FileLease) writeRecord(rec *leaseRecord) error {
+	bs, err := encoding.Marshal(rec)
+	if err != nil {
+		return err
+	}
+	tmpPath := fl.path + ".tmp"
+	if err := ioutil.WriteFile(tmpPath, bs, 0600); err != nil {
+		return err
+	}
+	if err := os.Rename(tmpPath, fl.path); err != nil {
+		return err
+	}
+	if written, err := ioutil.ReadFile(fl.path); err != nil {
+		return err
+	} else if !bytes.Equal(written, bs) {
+		return ErrLeaseLocked
+	}
+	return nil
+}
@@ -0,0 +1,166 @@
+package pof
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+)
+
+func testLeasePath(t *testing.T) (string, func()) {
+	dir, err := ioutil.TempDir("", "lease")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return filepath.Join(dir, "formulator.lease"), func() { os.RemoveAll(dir) }
+}
+
+func TestFileLeaseAcquire(t *testing.T) {
+	path, clear := testLeasePath(t)
+	defer clear()
+
+	a := NewFileLease(path, "a", 3*time.Second)
+	b := NewFileLease(path, "b", 3*time.Second)
+	if err := a.tryAcquire(); err != nil {
+		t.Fatal(err)
+	}
+	if !a.IsHolder() {
+		t.Fatal("a should hold the lease")
+	}
+	if err := b.tryAcquire(); err != nil {
+		t.Fatal(err)
+	}
+	if b.IsHolder() {
+		t.Fatal("b should not hold the lease of a")
+	}
+
+	a.Close()
+	if a.IsHolder() {
+		t.Fatal("a should release the lease when closed")
+	}
+	if err := b.tryAcquire(); err != nil {
+		t.Fatal(err)
+	}
+	if !b.IsHolder() {
+		t.Fatal("b should hold the released lease")
+	}
+}
+
+func TestFileLeaseRenew(t *testing.T) {
+	path, clear := testLeasePath(t)
+	defer clear()
+
+	a := NewFileLease(path, "a", 3*time.Second)
+	a.renew()
+	first := a.expireTime
+	if first == 0 {
+		t.Fatal("a should acquire the lease")
+	}
+	time.Sleep(10 * time.Millisecond)
+	a.renew()
+	if a.expireTime <= first {
+		t.Fatal("renew should extend the expire time")
+	}
+}
+
+func TestFileLeaseLockedKeepsExpireTime(t *testing.T) {
+	path, clear := testLeasePath(t)
+	defer clear()
+
+	TTL := 300 * time.Millisecond
+	a := NewFileLease(path, "a", TTL)
+	a.renew()
+	ExpireTime := a.expireTime
+	if ExpireTime == 0 {
+		t.Fatal("a should acquire the lease")
+	}
+
+	lockPath := path + ".lock"
+	if err := ioutil.WriteFile(lockPath, []byte("b"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	a.renew()
+	if a.expireTime != ExpireTime {
+		t.Fatal("a busy lock should not drop the lease before it expires")
+	}
+
+	time.Sleep(time.Duration(ExpireTime - time.Now().UnixNano()))
+	now := time.Now()
+	if err := os.Chtimes(lockPath, now, now); err != nil {
+		t.Fatal(err)
+	}
+	a.renew()
+	if a.expireTime != 0 {
+		t.Fatal("the lease should be dropped after it expires")
+	}
+	if bs, err := ioutil.ReadFile(lockPath); err != nil || string(bs) != "b" {
+		t.Fatal("a fresh lock of another process should be kept", err)
+	}
+}
+
+func TestFileLeaseStaleLock(t *testing.T) {
+	path, clear := testLeasePath(t)
+	defer clear()
+
+	TTL := 300 * time.Millisecond
+	lockPath := path + ".lock"
+	if err := ioutil.WriteFile(lockPath, []byte("crashed"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	old := time.Now().Add(-2 * TTL)
+	if err := os.Chtimes(lockPath, old, old); err != nil {
+		t.Fatal(err)
+	}
+
+	a := NewFileLease(path, "a", TTL)
+	if err := a.tryAcquire(); err != ErrLeaseLocked {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(lockPath); !os.IsNotExist(err) {
+		t.Fatal("the stale lock should be removed", err)
+	}
+	if err := a.tryAcquire(); err != nil {
+		t.Fatal(err)
+	}
+	if !a.IsHolder() {
+		t.Fatal("a should hold the lease after the stale lock is removed")
+	}
+}
+
+func TestFileLeaseContention(t *testing.T) {
+	path, clear := testLeasePath(t)
+	defer clear()
+
+	leases := []*FileLease{}
+	for _, id := range []string{"a", "b", "c", "d", "e", "f", "g", "h"} {
+		leases = append(leases, NewFileLease(path, id, 3*time.Second))
+	}
+	var wg sync.WaitGroup
+	for _, fl := range leases {
+		wg.Add(1)
+		go func(fl *FileLease) {
+			defer wg.Done()
+			for i := 0; i < 20; i++ {
+				fl.Lock()
+				fl.renew()
+				fl.Unlock()
+			}
+		}(fl)
+	}
+	wg.Wait()
+
+	Count := 0
+	for _, fl := range leases {
+		if fl.IsHolder() {
+			Count++
+		}
+	}
+	if Count != 1 {
+		t.Fatal("only one process should hold the lease", Count)
+	}
+	if _, err := os.Stat(path + ".lock"); !os.IsNotExist(err) {
+		t.Fatal("the lock should be released", err)
+	}
+}
@@ -32,6 +32,7 @@ type genItem struct {
 type FormulatorConfig struct {
 	Formulator              common.Address
 	MaxTransactionsPerBlock int
+	Lease                   FormulatorLease
 }
 
 // FormulatorNode procudes a block by the consensus
@@ -118,6 +119,14 @@ func (fr *FormulatorNode) Close() {
 	fr.cs.cn.Close()
 }
 
+// IsActive returns true when the formulator is allowed to respond to block requests
+func (fr *FormulatorNode) IsActive() bool {
+	if fr.Config.Lease == nil {
+		return true
+	}
+	return fr.Config.Lease.IsHolder()
+}
+
 // Init initializes formulator
 func (fr *FormulatorNode) Init() error {
 	fc := encoding.Factory("message")
@@ -322,7 +331,7 @@ func (fr *FormulatorNode) Run(BindAddress string) {
 
 			fr.lastReqLock.Lock()
 			if fr.lastReqMessage != nil {
-				if b.Header.Height <= fr.lastReqMessage.TargetHeight+fr.cs.maxBlocksPerFormulator {
+				if b.Header.Height <= fr.lastReqMessage.TargetHeight+fr.cs.MaxBlocksPerFormulator() {
 					if b.Header.Generator != fr.Config.Formulator {
 						fr.lastReqMessage = nil
 					}
@@ -60,6 +60,11 @@ func (fr *FormulatorNode) handleObserverMessage(p peer.Peer, m interface{}, Retr
 	case *BlockReqMessage:
 		rlog.Println("Formulator", fr.Config.Formulator.String(), "BlockReqMessage", msg.TargetHeight)
 
+		if !fr.IsActive() {
+			rlog.Println("Formulator", fr.Config.Formulator.String(), "Standby", msg.TargetHeight)
+			return nil
+		}
+
 		TargetHeight := fr.cs.cn.Provider().Height() + 1
 		if msg.TargetHeight < TargetHeight {
 			return nil
@@ -417,15 +422,19 @@ func (fr *FormulatorNode) updateByGenItem() {
 func (fr *FormulatorNode) genBlock(ID string, msg *BlockReqMessage) error {
 	cp := fr.cs.cn.Provider()
 
-	RemainBlocks := fr.cs.maxBlocksPerFormulator
+	MaxBlocksPerFormulator := fr.cs.MaxBlocksPerFormulator()
+	RemainBlocks := MaxBlocksPerFormulator
 	if msg.TimeoutCount == 0 {
-		RemainBlocks = fr.cs.maxBlocksPerFormulator - fr.cs.blocksBySameFormulator
+		RemainBlocks = MaxBlocksPerFormulator - fr.cs.blocksBySameFormulator
 	}
 
+	BlockInterval := fr.cs.BlockInterval()
+	BlockGenTimeout := fr.cs.BlockGenTimeout()
+
 	start := time.Now().UnixNano()
 	Now := uint64(time.Now().UnixNano())
 	StartBlockTime := Now
-	EndBlockTime := StartBlockTime + uint64(500*time.Millisecond)*uint64(RemainBlocks)
+	EndBlockTime := StartBlockTime + uint64(BlockInterval)*uint64(RemainBlocks)
 
 	LastTimestamp := cp.LastTimestamp()
 	if StartBlockTime < LastTimestamp {
@@ -444,7 +453,7 @@ func (fr *FormulatorNode) genBlock(ID string, msg *BlockReqMessage) error {
 			ctx = ctx.NextContext(encoding.Hash(lastHeader), lastHeader.Timestamp)
 		}
 
-		Timestamp := StartBlockTime + uint64(i)*uint64(500*time.Millisecond)
+		Timestamp := StartBlockTime + uint64(i)*uint64(BlockInterval)
 		if Timestamp > EndBlockTime {
 			Timestamp = EndBlockTime
 		}
@@ -462,7 +471,7 @@ func (fr *FormulatorNode) genBlock(ID string, msg *BlockReqMessage) error {
 			return err
 		}
 
-		timer := time.NewTimer(200 * time.Millisecond)
+		timer := time.NewTimer(BlockGenTimeout)
 
 		fr.txpool.Lock() // Prevent delaying from TxPool.Push
 		Count := 0
@@ -500,6 +509,9 @@ func (fr *FormulatorNode) genBlock(ID string, msg *BlockReqMessage) error {
 		}
 		lastHeader = &b.Header
 
+		if !fr.IsActive() {
+			return ErrLeaseLocked
+		}
 		if sig, err := fr.key.Sign(encoding.Hash(b.Header)); err != nil {
 			return err
 		} else {
@@ -516,11 +528,12 @@ func (fr *FormulatorNode) genBlock(ID string, msg *BlockReqMessage) error {
 		fr.lastGenHeight = ctx.TargetHeight()
 		fr.lastGenTime = time.Now().UnixNano()
 
-		ExpectedTime := 200*time.Millisecond + time.Duration(i)*500*time.Millisecond
+		LastIndex := MaxBlocksPerFormulator - 1
+		ExpectedTime := BlockGenTimeout + time.Duration(i)*BlockInterval
 		if i == 0 {
-			ExpectedTime = 200 * time.Millisecond
-		} else if i >= 9 {
-			ExpectedTime = 4200*time.Millisecond + time.Duration(i-9+1)*200*time.Millisecond
+			ExpectedTime = BlockGenTimeout
+		} else if i >= LastIndex {
+			ExpectedTime = BlockGenTimeout + time.Duration(LastIndex-1)*BlockInterval + time.Duration(i-LastIndex+1)*BlockGenTimeout
 		}
 		PastTime := time.Duration(time.Now().UnixNano() - start)
 		if ExpectedTime > PastTime {
@@ -46,6 +46,7 @@ type ObserverNode struct {
 	sendChan         chan *p2p.SendMessageItem
 	singleCache      gcache.Cache
 	batchCache       gcache.Cache
+	tracer           *RoundTracer
 	isRunning        bool
 	closeLock        sync.RWMutex
 	isClose          bool
@@ -58,7 +59,7 @@ func NewObserverNode(key key.Key, NetAddressMap map[common.PublicHash]string, cs
 	ob := &ObserverNode{
 		key:          key,
 		cs:           cs,
-		round:        NewVoteRound(cs.cn.Provider().Height()+1, cs.maxBlocksPerFormulator),
+		round:        NewVoteRound(cs.cn.Provider().Height()+1, cs.MaxBlocksPerFormulator()),
 		ignoreMap:    map[common.Address]int64{},
 		myPublicHash: common.NewPublicHash(key.PublicKey()),
 		statusMap:    map[string]*p2p.Status{},
@@ -68,7 +69,9 @@ func NewObserverNode(key key.Key, NetAddressMap map[common.PublicHash]string, cs
 		sendChan:     make(chan *p2p.SendMessageItem, 1000),
 		singleCache:  gcache.New(500).LRU().Build(),
 		batchCache:   gcache.New(500).LRU().Build(),
+		tracer:       NewRoundTracer(256),
 	}
+	ob.tracer.Begin(ob.round.TargetHeight)
 	ob.ms = NewObserverNodeMesh(key, NetAddressMap, ob)
 	ob.fs = NewFormulatorService(ob)
 	ob.requestTimer = p2p.NewRequestTimer(ob)
@@ -116,6 +119,19 @@ func (ob *ObserverNode) Init() error {
 			}
 			return nm, nil
 		})
+		js.Set("round", func(ID interface{}, arg *apiserver.Argument) (interface{}, error) {
+			ob.Lock()
+			defer ob.Unlock()
+
+			return NewRoundStatus(ob.round, ob.cs.observerKeyMap.Len()), nil
+		})
+		js.Set("roundTraces", func(ID interface{}, arg *apiserver.Argument) (interface{}, error) {
+			list, current := ob.tracer.Traces()
+			return map[string]interface{}{
+				"traces":  list,
+				"current": current,
+			}, nil
+		})
 	}
 	return nil
 }
@@ -268,7 +284,7 @@ func (ob *ObserverNode) Run(BindObserver string, BindFormulator string) {
 				}
 				if IsFailable {
 					ob.round.VoteFailCount++
-					if ob.round.VoteFailCount > 30 {
+					if ob.round.VoteFailCount > int(ob.cs.RoundTimeout()/(100*time.Millisecond)) {
 						if ob.round.MinRoundVoteAck != nil {
 							addr := ob.round.MinRoundVoteAck.Formulator
 							if _, has := ob.ignoreMap[addr]; has {
@@ -363,6 +379,7 @@ func (ob *ObserverNode) syncVoteRound() {
 			if br, has := ob.round.BlockRoundMap[TargetHeight]; has {
 				ob.round.TargetHeight = TargetHeight
 				ob.round.RoundState = BlockWaitState
+				ob.tracer.Phase(ob.round.RoundState, ob.round.TargetHeight)
 				if br.BlockGenMessageWait != nil && br.BlockGenMessage == nil {
 					ob.messageQueue.Push(&messageItem{
 						Message: br.BlockGenMessageWait,
@@ -382,7 +399,13 @@ func (ob *ObserverNode) syncVoteRound() {
 }
 
 func (ob *ObserverNode) resetVoteRound(resetStat bool) {
-	ob.round = NewVoteRound(ob.cs.cn.Provider().Height()+1, ob.cs.maxBlocksPerFormulator)
+	if resetStat {
+		ob.tracer.End("failed")
+	} else {
+		ob.tracer.End("turned_over")
+	}
+	ob.round = NewVoteRound(ob.cs.cn.Provider().Height()+1, ob.cs.MaxBlocksPerFormulator())
+	ob.tracer.Begin(ob.round.TargetHeight)
 	ob.prevRoundEndTime = time.Now().UnixNano()
 	if resetStat {
 		ob.roundFirstTime = 0
@@ -111,6 +111,7 @@ func (ob *ObserverNode) handleObserverMessage(SenderPublicHash common.PublicHash
 		}
 		if len(ob.round.RoundVoteMessageMap) >= ob.cs.observerKeyMap.Len()/2+2 {
 			ob.round.RoundState = RoundVoteAckState
+			ob.tracer.Phase(ob.round.RoundState, ob.round.TargetHeight)
 			if ob.roundFirstTime == 0 {
 				ob.roundFirstTime = uint64(time.Now().UnixNano())
 				ob.roundFirstHeight = uint32(cp.Height())
@@ -222,10 +223,11 @@ func (ob *ObserverNode) handleObserverMessage(SenderPublicHash common.PublicHash
 			if MinRoundVoteAck != nil {
 				ob.round.RoundState = BlockWaitState
 				ob.round.MinRoundVoteAck = MinRoundVoteAck
+				ob.tracer.Phase(ob.round.RoundState, ob.round.TargetHeight)
 				ob.round.VoteFailCount = 0
-				RemainBlocks := ob.cs.maxBlocksPerFormulator
+				RemainBlocks := ob.cs.MaxBlocksPerFormulator()
 				if MinRoundVoteAck.TimeoutCount == 0 {
-					RemainBlocks = ob.cs.maxBlocksPerFormulator - ob.cs.blocksBySameFormulator
+					RemainBlocks = ob.cs.MaxBlocksPerFormulator() - ob.cs.blocksBySameFormulator
 				}
 				for TargetHeight, br := range ob.round.BlockRoundMap {
 					if TargetHeight >= ob.round.TargetHeight+RemainBlocks {
@@ -402,6 +404,7 @@ func (ob *ObserverNode) handleObserverMessage(SenderPublicHash common.PublicHash
 		}
 
 		ob.round.RoundState = BlockVoteState
+		ob.tracer.Phase(ob.round.RoundState, ob.round.TargetHeight)
 		br.BlockGenMessage = msg
 		br.Context = ctx
 
@@ -576,17 +579,22 @@ func (ob *ObserverNode) handleObserverMessage(SenderPublicHash common.PublicHash
 
 		//[apply vote]
 		if len(br.BlockVoteMap) >= ob.cs.observerKeyMap.Len()/2+1 {
-			sigs := []common.Signature{}
-			for _, vt := range br.BlockVoteMap {
-				sigs = append(sigs, vt.ObserverSignature)
+			SigMap := map[common.PublicHash]common.Signature{}
+			for pubhash, vt := range br.BlockVoteMap {
+				SigMap[pubhash] = vt.ObserverSignature
+			}
+			sigs, err := ob.cs.PackObserverSignatures(msg.BlockVote.Header.Version, s, SigMap)
+			if err != nil {
+				return err
 			}
 
 			PastTime := uint64(time.Now().UnixNano()) - ob.roundFirstTime
-			ExpectedTime := uint64(msg.BlockVote.Header.Height-ob.roundFirstHeight) * uint64(500*time.Millisecond)
+			BlockInterval := ob.cs.BlockInterval()
+			ExpectedTime := uint64(msg.BlockVote.Header.Height-ob.roundFirstHeight) * uint64(BlockInterval)
 			if PastTime < ExpectedTime {
 				diff := time.Duration(ExpectedTime - PastTime)
-				if diff > 500*time.Millisecond {
-					diff = 500 * time.Millisecond
+				if diff > BlockInterval {
+					diff = BlockInterval
 				}
 				time.Sleep(diff)
 			}
@@ -664,6 +672,7 @@ func (ob *ObserverNode) handleObserverMessage(SenderPublicHash common.PublicHash
 				ob.round.RoundState = BlockWaitState
 				ob.round.VoteFailCount = 0
 				ob.round.TargetHeight++
+				ob.tracer.Phase(ob.round.RoundState, ob.round.TargetHeight)
 				if brNext.BlockGenMessageWait != nil && brNext.BlockGenMessage == nil {
 					ob.messageQueue.Push(&messageItem{
 						Message: brNext.BlockGenMessageWait,
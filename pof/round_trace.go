@@ -0,0 +1,435 @@
+package pof
+
+import (
+	"bytes"
+	"encoding/json"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/fletaio/fleta/common"
+)
+
+// RoundStateName returns the readable name of the round state
+func RoundStateName(state int) string {
+	switch state {
+	case EmptyState:
+		return "empty"
+	case RoundVoteState:
+		return "round_vote"
+	case RoundVoteAckState:
+		return "round_vote_ack"
+	case BlockWaitState:
+		return "block_wait"
+	case BlockVoteState:
+		return "block_vote"
+	default:
+		return "unknown"
+	}
+}
+
+// RoundPhase is a phase transition of the vote round
+type RoundPhase struct {
+	State  string
+	Height uint32
+	Time   int64
+}
+
+// MarshalJSON is a marshaler function
+func (ph *RoundPhase) MarshalJSON() ([]byte, error) {
+	var buffer bytes.Buffer
+	buffer.WriteString(`{`)
+	buffer.WriteString(`"state":`)
+	if bs, err := json.Marshal(ph.State); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"height":`)
+	if bs, err := json.Marshal(ph.Height); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"time":`)
+	if bs, err := json.Marshal(ph.Time); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`}`)
+	return buffer.Bytes(), nil
+}
+
+// RoundTrace records phase transitions of the vote round
+type RoundTrace struct {
+	TargetHeight uint32
+	BeginTime    int64
+	EndTime      int64
+	Result       string
+	Phases       []*RoundPhase
+}
+
+// MarshalJSON is a marshaler function
+func (tr *RoundTrace) MarshalJSON() ([]byte, error) {
+	var buffer bytes.Buffer
+	buffer.WriteString(`{`)
+	buffer.WriteString(`"target_height":`)
+	if bs, err := json.Marshal(tr.TargetHeight); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"begin_time":`)
+	if bs, err := json.Marshal(tr.BeginTime); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"end_time":`)
+	if bs, err := json.Marshal(tr.EndTime); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"result":`)
+	if bs, err := json.Marshal(tr.Result); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"phases":`)
+	buffer.WriteString(`[`)
+	for i, ph := range tr.Phases {
+		if i > 0 {
+			buffer.WriteString(`,`)
+		}
+		if bs, err := ph.MarshalJSON(); err != nil {
+			return nil, err
+		} else {
+			buffer.Write(bs)
+		}
+	}
+	buffer.WriteString(`]`)
+	buffer.WriteString(`}`)
+	return buffer.Bytes(), nil
+}
+
+// RoundTracer keeps traces of recent vote rounds in a ring buffer
+type RoundTracer struct {
+	sync.Mutex
+	traces  []*RoundTrace
+	head    int
+	count   int
+	current *RoundTrace
+}
+
+// NewRoundTracer returns a RoundTracer
+func NewRoundTracer(Size int) *RoundTracer {
+	if Size <= 0 {
+		Size = 1
+	}
+	rt := &RoundTracer{
+		traces: make([]*RoundTrace, Size),
+	}
+	return rt
+}
+
+// Begin starts the trace of the round
+func (rt *RoundTracer) Begin(TargetHeight uint32) {
+	rt.Lock()
+	defer rt.Unlock()
+
+	rt.current = &RoundTrace{
+		TargetHeight: TargetHeight,
+		BeginTime:    time.Now().UnixNano(),
+		Phases:       []*RoundPhase{},
+	}
+}
+
+// Phase records the phase transition of the current round
+func (rt *RoundTracer) Phase(State int, Height uint32) {
+	rt.Lock()
+	defer rt.Unlock()
+
+	if rt.current == nil {
+		return
+	}
+	rt.current.Phases = append(rt.current.Phases, &RoundPhase{
+		State:  RoundStateName(State),
+		Height: Height,
+		Time:   time.Now().UnixNano(),
+	})
+}
+
+// End finishes the trace of the current round and pushes it to the ring buffer
+func (rt *RoundTracer) End(Result string) {
+	rt.Lock()
+	defer rt.Unlock()
+
+	if rt.current == nil {
+		return
+	}
+	rt.current.EndTime = time.Now().UnixNano()
+	rt.current.Result = Result
+	rt.traces[rt.head] = rt.current
+	rt.head = (rt.head + 1) % len(rt.traces)
+	if rt.count < len(rt.traces) {
+		rt.count++
+	}
+	rt.current = nil
+}
+
+// Traces returns finished traces from the oldest and the trace of the current round
+func (rt *RoundTracer) Traces() ([]*RoundTrace, *RoundTrace) {
+	rt.Lock()
+	defer rt.Unlock()
+
+	list := make([]*RoundTrace, 0, rt.count)
+	start := (rt.head - rt.count + len(rt.traces)) % len(rt.traces)
+	for i := 0; i < rt.count; i++ {
+		list = append(list, rt.traces[(start+i)%len(rt.traces)])
+	}
+	var current *RoundTrace
+	if rt.current != nil {
+		phases := make([]*RoundPhase, len(rt.current.Phases))
+		copy(phases, rt.current.Phases)
+		current = &RoundTrace{
+			TargetHeight: rt.current.TargetHeight,
+			BeginTime:    rt.current.BeginTime,
+			Phases:       phases,
+		}
+	}
+	return list, current
+}
+
+// BlockRoundStatus is the progress of the block round
+type BlockRoundStatus struct {
+	Height          uint32
+	HasBlockGen     bool
+	HasBlockGenWait bool
+	BlockVotes      []common.PublicHash
+	BlockVoteWaits  int
+}
+
+// MarshalJSON is a marshaler function
+func (br *BlockRoundStatus) MarshalJSON() ([]byte, error) {
+	var buffer bytes.Buffer
+	buffer.WriteString(`{`)
+	buffer.WriteString(`"height":`)
+	if bs, err := json.Marshal(br.Height); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"has_block_gen":`)
+	if bs, err := json.Marshal(br.HasBlockGen); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"has_block_gen_wait":`)
+	if bs, err := json.Marshal(br.HasBlockGenWait); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"block_votes":`)
+	buffer.WriteString(`[`)
+	for i, pubhash := range br.BlockVotes {
+		if i > 0 {
+			buffer.WriteString(`,`)
+		}
+		if bs, err := pubhash.MarshalJSON(); err != nil {
+			return nil, err
+		} else {
+			buffer.Write(bs)
+		}
+	}
+	buffer.WriteString(`]`)
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"block_vote_waits":`)
+	if bs, err := json.Marshal(br.BlockVoteWaits); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`}`)
+	return buffer.Bytes(), nil
+}
+
+// RoundStatus is the snapshot of the vote round
+type RoundStatus struct {
+	State           string
+	TargetHeight    uint32
+	VoteFailCount   int
+	RoundVotes      []common.PublicHash
+	RoundVoteAcks   []common.PublicHash
+	Formulator      *common.Address
+	BlockRounds     []*BlockRoundStatus
+	ObserverCount   int
+	RequiredVotes   int
+	RequiredVoteAck int
+}
+
+// MarshalJSON is a marshaler function
+func (rs *RoundStatus) MarshalJSON() ([]byte, error) {
+	var buffer bytes.Buffer
+	buffer.WriteString(`{`)
+	buffer.WriteString(`"state":`)
+	if bs, err := json.Marshal(rs.State); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"target_height":`)
+	if bs, err := json.Marshal(rs.TargetHeight); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"vote_fail_count":`)
+	if bs, err := json.Marshal(rs.VoteFailCount); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"round_votes":`)
+	buffer.WriteString(`[`)
+	for i, pubhash := range rs.RoundVotes {
+		if i > 0 {
+			buffer.WriteString(`,`)
+		}
+		if bs, err := pubhash.MarshalJSON(); err != nil {
+			return nil, err
+		} else {
+			buffer.Write(bs)
+		}
+	}
+	buffer.WriteString(`]`)
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"round_vote_acks":`)
+	buffer.WriteString(`[`)
+	for i, pubhash := range rs.RoundVoteAcks {
+		if i > 0 {
+			buffer.WriteString(`,`)
+		}
+		if bs, err := pubhash.MarshalJSON(); err != nil {
+			return nil, err
+		} else {
+			buffer.Write(bs)
+		}
+	}
+	buffer.WriteString(`]`)
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"formulator":`)
+	if rs.Formulator == nil {
+		buffer.WriteString(`null`)
+	} else {
+		if bs, err := rs.Formulator.MarshalJSON(); err != nil {
+			return nil, err
+		} else {
+			buffer.Write(bs)
+		}
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"block_rounds":`)
+	buffer.WriteString(`[`)
+	for i, br := range rs.BlockRounds {
+		if i > 0 {
+			buffer.WriteString(`,`)
+		}
+		if bs, err := br.MarshalJSON(); err != nil {
+			return nil, err
+		} else {
+			buffer.Write(bs)
+		}
+	}
+	buffer.WriteString(`]`)
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"observer_count":`)
+	if bs, err := json.Marshal(rs.ObserverCount); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"required_votes":`)
+	if bs, err := json.Marshal(rs.RequiredVotes); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"required_vote_acks":`)
+	if bs, err := json.Marshal(rs.RequiredVoteAck); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`}`)
+	return buffer.Bytes(), nil
+}
+
+func sortPublicHashes(list []common.PublicHash) {
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].Less(list[j])
+	})
+}
+
+// NewRoundStatus returns the snapshot of the vote round
+func NewRoundStatus(vr *VoteRound, ObserverCount int) *RoundStatus {
+	rs := &RoundStatus{
+		State:           RoundStateName(vr.RoundState),
+		TargetHeight:    vr.TargetHeight,
+		VoteFailCount:   vr.VoteFailCount,
+		RoundVotes:      make([]common.PublicHash, 0, len(vr.RoundVoteMessageMap)),
+		RoundVoteAcks:   make([]common.PublicHash, 0, len(vr.RoundVoteAckMessageMap)),
+		BlockRounds:     make([]*BlockRoundStatus, 0, len(vr.BlockRoundMap)),
+		ObserverCount:   ObserverCount,
+		RequiredVotes:   ObserverCount/2 + 2,
+		RequiredVoteAck: ObserverCount/2 + 1,
+	}
+	for pubhash := range vr.RoundVoteMessageMap {
+		rs.RoundVotes = append(rs.RoundVotes, pubhash)
+	}
+	sortPublicHashes(rs.RoundVotes)
+	for pubhash := range vr.RoundVoteAckMessageMap {
+		rs.RoundVoteAcks = append(rs.RoundVoteAcks, pubhash)
+	}
+	sortPublicHashes(rs.RoundVoteAcks)
+	if vr.MinRoundVoteAck != nil {
+		addr := vr.MinRoundVoteAck.Formulator
+		rs.Formulator = &addr
+	}
+	for Height, br := range vr.BlockRoundMap {
+		bs := &BlockRoundStatus{
+			Height:          Height,
+			HasBlockGen:     br.BlockGenMessage != nil,
+			HasBlockGenWait: br.BlockGenMessageWait != nil,
+			BlockVotes:      make([]common.PublicHash, 0, len(br.BlockVoteMap)),
+			BlockVoteWaits:  len(br.BlockVoteMessageWaitMap),
+		}
+		for pubhash := range br.BlockVoteMap {
+			bs.BlockVotes = append(bs.BlockVotes, pubhash)
+		}
+		sortPublicHashes(bs.BlockVotes)
+		rs.BlockRounds = append(rs.BlockRounds, bs)
+	}
+	sort.Slice(rs.BlockRounds, func(i, j int) bool {
+		return rs.BlockRounds[i].Height < rs.BlockRounds[j].Height
+	})
+	return rs
+}
@@ -0,0 +1,101 @@
+package pof
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/fletaio/fleta/common"
+	"github.com/fletaio/fleta/common/hash"
+)
+
+// ObserverSignatureScheme defines how observer signatures of a block are packed and validated
+type ObserverSignatureScheme interface {
+	Pack(signedHash hash.Hash256, SigMap map[common.PublicHash]common.Signature, ObserverKeys []common.PublicHash) ([]common.Signature, error)
+	Validate(signedHash hash.Hash256, sigs []common.Signature, ObserverKeys []common.PublicHash) error
+}
+
+type schemeEntry struct {
+	fromVersion uint16
+	scheme      ObserverSignatureScheme
+}
+
+var (
+	schemeLock sync.Mutex
+	schemes    = []*schemeEntry{
+		{
+			fromVersion: 0,
+			scheme:      &individualSignatureScheme{},
+		},
+	}
+)
+
+// RegisterObserverSignatureScheme applies the scheme to blocks whose header version is greater than or equal to FromVersion
+func RegisterObserverSignatureScheme(FromVersion uint16, scheme ObserverSignatureScheme) error {
+	schemeLock.Lock()
+	defer schemeLock.Unlock()
+
+	for _, v := range schemes {
+		if v.fromVersion == FromVersion {
+			return ErrExistSignatureSchemeVersion
+		}
+	}
+	schemes = append(schemes, &schemeEntry{
+		fromVersion: FromVersion,
+		scheme:      scheme,
+	})
+	sort.Slice(schemes, func(i, j int) bool {
+		return schemes[i].fromVersion < schemes[j].fromVersion
+	})
+	return nil
+}
+
+// ObserverSignatureSchemeByVersion returns the scheme used by the header version
+func ObserverSignatureSchemeByVersion(Version uint16) ObserverSignatureScheme {
+	schemeLock.Lock()
+	defer schemeLock.Unlock()
+
+	var scheme ObserverSignatureScheme
+	for _, v := range schemes {
+		if v.fromVersion > Version {
+			break
+		}
+		scheme = v.scheme
+	}
+	return scheme
+}
+
+// individualSignatureScheme stores the majority of recoverable observer signatures as they are
+type individualSignatureScheme struct{}
+
+// Pack returns the majority of signatures ordered by the observer key list
+func (s *individualSignatureScheme) Pack(signedHash hash.Hash256, SigMap map[common.PublicHash]common.Signature, ObserverKeys []common.PublicHash) ([]common.Signature, error) {
+	Required := len(ObserverKeys)/2 + 1
+	sigs := make([]common.Signature, 0, Required)
+	for _, pubhash := range ObserverKeys {
+		if len(sigs) >= Required {
+			break
+		}
+		if sig, has := SigMap[pubhash]; has {
+			sigs = append(sigs, sig)
+		}
+	}
+	if len(sigs) != Required {
+		return nil, common.ErrInsufficientSignature
+	}
+	return sigs, nil
+}
+
+// Validate recovers each signer and checks the majority of observers
+func (s *individualSignatureScheme) Validate(signedHash hash.Hash256, sigs []common.Signature, ObserverKeys []common.PublicHash) error {
+	KeyMap := map[common.PublicHash]bool{}
+	for _, pubhash := range ObserverKeys {
+		KeyMap[pubhash] = true
+	}
+	if len(KeyMap) != len(ObserverKeys) {
+		return ErrInvalidObserverKey
+	}
+	if err := common.ValidateSignaturesMajority(signedHash, sigs, KeyMap); err != nil {
+		return err
+	}
+	return nil
+}
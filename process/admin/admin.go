@@ -3,14 +3,16 @@ package admin
 import (
 	"github.com/fletaio/fleta/common"
 	"github.com/fletaio/fleta/core/types"
+	"github.com/fletaio/fleta/service/apiserver"
 )
 
 // Admin manages balance of accounts of the chain
 type Admin struct {
 	*types.ProcessBase
-	pid uint8
-	pm  types.ProcessManager
-	cn  types.Provider
+	pid        uint8
+	pm         types.ProcessManager
+	cn         types.Provider
+	isGoverned bool
 }
 
 // NewAdmin returns a Admin
@@ -50,6 +52,51 @@ func (p *Admin) InitAdmin(ctw *types.ContextWrapper, addrMap map[string]common.A
 func (p *Admin) Init(reg *types.Register, pm types.ProcessManager, cn types.Provider) error {
 	p.pm = pm
 	p.cn = cn
+
+	if _, err := pm.ProcessByName("fleta.governance"); err == nil {
+		p.isGoverned = true
+	}
+
+	reg.RegisterTransaction(1, &GrantRole{})
+	reg.RegisterTransaction(2, &RevokeRole{})
+	reg.RegisterTransaction(3, &TransferAdmin{})
+
+	if vs, err := pm.ServiceByName("fleta.apiserver"); err != nil {
+		//ignore when not loaded
+	} else if v, is := vs.(*apiserver.APIServer); !is {
+		//ignore when not loaded
+	} else {
+		s, err := v.JRPC("admin")
+		if err != nil {
+			return err
+		}
+		s.Set("adminAddress", func(ID interface{}, arg *apiserver.Argument) (interface{}, error) {
+			if arg.Len() != 1 {
+				return nil, apiserver.ErrInvalidArgument
+			}
+			name, err := arg.String(0)
+			if err != nil {
+				return nil, err
+			}
+			loader := cn.NewLoaderWrapper(p.ID())
+			return p.AdminAddress(loader, name)
+		})
+		s.Set("roleMembers", func(ID interface{}, arg *apiserver.Argument) (interface{}, error) {
+			if arg.Len() != 2 {
+				return nil, apiserver.ErrInvalidArgument
+			}
+			name, err := arg.String(0)
+			if err != nil {
+				return nil, err
+			}
+			Role, err := arg.String(1)
+			if err != nil {
+				return nil, err
+			}
+			loader := cn.NewLoaderWrapper(p.ID())
+			return p.RoleMembers(loader, name, Role)
+		})
+	}
 	return nil
 }
 
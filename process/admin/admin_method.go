@@ -1,19 +1,100 @@
 package admin
 
 import (
+	"bytes"
+
 	"github.com/fletaio/fleta/common"
 	"github.com/fletaio/fleta/core/types"
 )
 
 // AdminAddress returns the admin address
-func (p *Admin) AdminAddress(loader types.Loader, name string) common.Address {
+func (p *Admin) AdminAddress(loader types.Loader, name string) (common.Address, error) {
 	lw := types.NewLoaderWrapper(p.pid, loader)
 
 	if bs := lw.ProcessData(toAdminAddressKey(name)); len(bs) == 0 {
-		panic(ErrNotExistAdminAddress)
+		return common.Address{}, ErrNotExistAdminAddress
 	} else {
 		var addr common.Address
 		copy(addr[:], bs)
-		return addr
+		return addr, nil
+	}
+}
+
+// IsGoverned returns true when the governance process is loaded
+// policy update transactions of the governed chain are only executed by proposals
+func (p *Admin) IsGoverned() bool {
+	return p.isGoverned
+}
+
+// HasRole returns true when the address is the admin of the process or a member of the role
+// RoleAdmin is only held by the admin address
+func (p *Admin) HasRole(loader types.Loader, name string, Role string, addr common.Address) (bool, error) {
+	if AdminAddress, err := p.AdminAddress(loader, name); err != nil {
+		if err != ErrNotExistAdminAddress {
+			return false, err
+		}
+	} else if AdminAddress == addr {
+		return true, nil
+	}
+	if Role == RoleAdmin {
+		return false, nil
+	}
+	return p.HasRoleMember(loader, name, Role, addr)
+}
+
+// HasRoleMember returns true when the address is granted the role of the process
+func (p *Admin) HasRoleMember(loader types.Loader, name string, Role string, addr common.Address) (bool, error) {
+	members, err := p.RoleMembers(loader, name, Role)
+	if err != nil {
+		return false, err
+	}
+	for _, v := range members {
+		if v == addr {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// RoleMembers returns addresses granted the role of the process
+func (p *Admin) RoleMembers(loader types.Loader, name string, Role string) ([]common.Address, error) {
+	lw := types.NewLoaderWrapper(p.pid, loader)
+
+	members := []common.Address{}
+	bs := lw.ProcessData(toRoleMemberKey(name, Role))
+	for i := 0; i+common.AddressSize <= len(bs); i += common.AddressSize {
+		var addr common.Address
+		copy(addr[:], bs[i:])
+		members = append(members, addr)
+	}
+	return members, nil
+}
+
+func (p *Admin) addRoleMember(ctw *types.ContextWrapper, name string, Role string, addr common.Address) error {
+	if has, err := p.HasRoleMember(ctw, name, Role, addr); err != nil {
+		return err
+	} else if has {
+		return ErrExistRoleMember
+	}
+	bs := ctw.ProcessData(toRoleMemberKey(name, Role))
+	ctw.SetProcessData(toRoleMemberKey(name, Role), append(bs, addr[:]...))
+	return nil
+}
+
+func (p *Admin) removeRoleMember(ctw *types.ContextWrapper, name string, Role string, addr common.Address) error {
+	bs := ctw.ProcessData(toRoleMemberKey(name, Role))
+	for i := 0; i+common.AddressSize <= len(bs); i += common.AddressSize {
+		if bytes.Equal(bs[i:i+common.AddressSize], addr[:]) {
+			nbs := make([]byte, 0, len(bs)-common.AddressSize)
+			nbs = append(nbs, bs[:i]...)
+			nbs = append(nbs, bs[i+common.AddressSize:]...)
+			if len(nbs) == 0 {
+				ctw.SetProcessData(toRoleMemberKey(name, Role), nil)
+			} else {
+				ctw.SetProcessData(toRoleMemberKey(name, Role), nbs)
+			}
+			return nil
+		}
 	}
+	return ErrNotExistRoleMember
 }
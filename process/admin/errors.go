@@ -7,4 +7,8 @@ var (
 	ErrInvalidAdminAddress     = errors.New("invalid admin address")
 	ErrUnauthorizedTransaction = errors.New("unauthorized transaction")
 	ErrNotExistAdminAddress    = errors.New("not exist admin address")
+	ErrInvalidRole             = errors.New("invalid role")
+	ErrExistRoleMember         = errors.New("exist role member")
+	ErrNotExistRoleMember      = errors.New("not exist role member")
+	ErrGovernedTransaction     = errors.New("governed transaction")
 )
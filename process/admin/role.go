@@ -0,0 +1,21 @@
+package admin
+
+// roles of process actions
+const (
+	RoleAdmin        = "admin"
+	RolePolicy       = "policy"
+	RoleIssueAccount = "issue_account"
+	RoleTokenIn      = "token_in"
+)
+
+// ValidateRole checks that the role can be granted
+// RoleAdmin cannot be granted because it is held by the admin address only
+func ValidateRole(Role string) error {
+	if len(Role) == 0 || len(Role) > 32 {
+		return ErrInvalidRole
+	}
+	if Role == RoleAdmin {
+		return ErrInvalidRole
+	}
+	return nil
+}
@@ -0,0 +1,125 @@
+package admin
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/fletaio/fleta/common"
+	"github.com/fletaio/fleta/core/types"
+)
+
+// RevokeRole removes the address from the role of the process by the admin of the process
+type RevokeRole struct {
+	Timestamp_ uint64
+	Seq_       uint64
+	From_      common.Address
+	Name       string
+	Role       string
+	Address    common.Address
+}
+
+// Timestamp returns the timestamp of the transaction
+func (tx *RevokeRole) Timestamp() uint64 {
+	return tx.Timestamp_
+}
+
+// Seq returns the sequence of the transaction
+func (tx *RevokeRole) Seq() uint64 {
+	return tx.Seq_
+}
+
+// From returns the from address of the transaction
+func (tx *RevokeRole) From() common.Address {
+	return tx.From_
+}
+
+// Validate validates signatures of the transaction
+func (tx *RevokeRole) Validate(p types.Process, loader types.LoaderWrapper, signers []common.PublicHash) error {
+	sp := p.(*Admin)
+
+	if err := ValidateRole(tx.Role); err != nil {
+		return err
+	}
+	if tx.Seq() <= loader.Seq(tx.From()) {
+		return types.ErrInvalidSequence
+	}
+
+	if AdminAddress, err := sp.AdminAddress(loader, tx.Name); err != nil {
+		return err
+	} else if tx.From() != AdminAddress {
+		return ErrUnauthorizedTransaction
+	}
+	if has, err := sp.HasRoleMember(loader, tx.Name, tx.Role, tx.Address); err != nil {
+		return err
+	} else if !has {
+		return ErrNotExistRoleMember
+	}
+
+	fromAcc, err := loader.Account(tx.From())
+	if err != nil {
+		return err
+	}
+	if err := fromAcc.Validate(loader, signers); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Execute updates the context by the transaction
+func (tx *RevokeRole) Execute(p types.Process, ctw *types.ContextWrapper, index uint16) error {
+	sp := p.(*Admin)
+
+	if err := sp.removeRoleMember(ctw, tx.Name, tx.Role, tx.Address); err != nil {
+		return err
+	}
+	return nil
+}
+
+// MarshalJSON is a marshaler function
+func (tx *RevokeRole) MarshalJSON() ([]byte, error) {
+	var buffer bytes.Buffer
+	buffer.WriteString(`{`)
+	buffer.WriteString(`"timestamp":`)
+	if bs, err := json.Marshal(tx.Timestamp_); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"seq":`)
+	if bs, err := json.Marshal(tx.Seq_); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"from":`)
+	if bs, err := tx.From_.MarshalJSON(); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"name":`)
+	if bs, err := json.Marshal(tx.Name); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"role":`)
+	if bs, err := json.Marshal(tx.Role); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"address":`)
+	if bs, err := tx.Address.MarshalJSON(); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`}`)
+	return buffer.Bytes(), nil
+}
@@ -3,6 +3,7 @@ package admin
 // tags
 var (
 	tagAdminAddress = []byte{1, 1}
+	tagRoleMember   = []byte{2, 1}
 )
 
 func toAdminAddressKey(Name string) []byte {
@@ -11,3 +12,12 @@ func toAdminAddressKey(Name string) []byte {
 	copy(bs[2:], []byte(Name))
 	return bs
 }
+
+func toRoleMemberKey(Name string, Role string) []byte {
+	bs := make([]byte, 3+len(Name)+len(Role))
+	copy(bs, tagRoleMember)
+	bs[2] = byte(len(Name))
+	copy(bs[3:], []byte(Name))
+	copy(bs[3+len(Name):], []byte(Role))
+	return bs
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/fletaio/fleta/common"
 	"github.com/fletaio/fleta/common/amount"
 	"github.com/fletaio/fleta/core/types"
+	"github.com/fletaio/fleta/process/vault"
 )
 
 // FormulatorType is type of formulator account
@@ -57,6 +58,19 @@ func (acc *FormulatorAccount) GeneratorHash() common.PublicHash {
 	return acc.GenHash
 }
 
+// ValidateRecoveryKeys checks keys of the recovery can be applied to the account
+func (acc *FormulatorAccount) ValidateRecoveryKeys(Required uint8, KeyHashes []common.PublicHash) error {
+	if Required != 1 || len(KeyHashes) != 1 {
+		return vault.ErrInvalidRequiredKeyHashCount
+	}
+	return nil
+}
+
+// ApplyRecoveryKeys replaces the owner key of the account by the recovery
+func (acc *FormulatorAccount) ApplyRecoveryKeys(Required uint8, KeyHashes []common.PublicHash) {
+	acc.KeyHash = KeyHashes[0]
+}
+
 // IsActivated returns it is activated or not
 func (acc *FormulatorAccount) IsActivated() bool {
 	return !acc.IsRevoked
@@ -36,7 +36,11 @@ var (
 	ErrInvalidTransmuteHeight                  = errors.New("invalid transmute height")
 	ErrNotExistTransmutePolicy                 = errors.New("not exist transmute policy")
 	ErrNotExistMiningFeePolicy                 = errors.New("not exist mining fee policy")
+	ErrNotExistConsensusPolicy                 = errors.New("not exist consensus policy")
+	ErrInvalidConsensusPolicy                  = errors.New("invalid consensus policy")
+	ErrInvalidActivateHeight                   = errors.New("invalid activate height")
 	ErrNoOverAmount                            = errors.New("no over amount")
 	ErrSigmaCreationNotAllowed                 = errors.New("sigma creation not allowed")
 	ErrOmegaCreationNotAllowed                 = errors.New("omega creation not allowed")
+	ErrRedelegateCooldown                      = errors.New("redelegate cooldown")
 )
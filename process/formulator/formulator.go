@@ -7,6 +7,7 @@ import (
 	"github.com/fletaio/fleta/encoding"
 	"github.com/fletaio/fleta/process/admin"
 	"github.com/fletaio/fleta/process/vault"
+	"github.com/fletaio/fleta/service/apiserver"
 )
 
 // Formulator serves reward system of the chain
@@ -84,9 +85,179 @@ func (p *Formulator) Init(reg *types.Register, pm types.ProcessManager, cn types
 	reg.RegisterTransaction(19, &WithdrawOverAmount{})
 	reg.RegisterTransaction(20, &ChangeStaking{})
 	reg.RegisterTransaction(21, &UpdateMiningFeePolicy{})
+	reg.RegisterTransaction(22, &UpdateConsensusPolicy{})
+	reg.RegisterTransaction(23, &Redelegate{})
 	reg.RegisterEvent(1, &RewardEvent{})
 	reg.RegisterEvent(2, &RevokedEvent{})
 	reg.RegisterEvent(3, &UnstakedEvent{})
+
+	if vs, err := pm.ServiceByName("fleta.apiserver"); err != nil {
+		//ignore when not loaded
+	} else if v, is := vs.(*apiserver.APIServer); !is {
+		//ignore when not loaded
+	} else {
+		s, err := v.JRPC("formulator")
+		if err != nil {
+			return err
+		}
+		s.Set("rewardPolicy", func(ID interface{}, arg *apiserver.Argument) (interface{}, error) {
+			loader := cn.NewLoaderWrapper(p.ID())
+			return p.GetRewardPolicy(loader)
+		})
+		s.Set("validatorPolicy", func(ID interface{}, arg *apiserver.Argument) (interface{}, error) {
+			if arg.Len() != 1 {
+				return nil, apiserver.ErrInvalidArgument
+			}
+			arg0, err := arg.String(0)
+			if err != nil {
+				return nil, err
+			}
+			HyperAddress, err := common.ParseAddress(arg0)
+			if err != nil {
+				return nil, err
+			}
+			loader := cn.NewLoaderWrapper(p.ID())
+			acc, err := loader.Account(HyperAddress)
+			if err != nil {
+				return nil, err
+			}
+			frAcc, is := acc.(*FormulatorAccount)
+			if !is || frAcc.FormulatorType != HyperFormulatorType {
+				return nil, types.ErrInvalidAccountType
+			}
+			return frAcc.Policy, nil
+		})
+		s.Set("stakingAmountMap", func(ID interface{}, arg *apiserver.Argument) (interface{}, error) {
+			if arg.Len() != 1 {
+				return nil, apiserver.ErrInvalidArgument
+			}
+			arg0, err := arg.String(0)
+			if err != nil {
+				return nil, err
+			}
+			HyperAddress, err := common.ParseAddress(arg0)
+			if err != nil {
+				return nil, err
+			}
+			loader := cn.NewLoaderWrapper(p.ID())
+			AmountMap, err := p.GetStakingAmountMap(loader, HyperAddress)
+			if err != nil {
+				return nil, err
+			}
+			m := map[string]*amount.Amount{}
+			for addr, am := range AmountMap {
+				m[addr.String()] = am
+			}
+			return m, nil
+		})
+		s.Set("stakingAmount", func(ID interface{}, arg *apiserver.Argument) (interface{}, error) {
+			if arg.Len() != 2 {
+				return nil, apiserver.ErrInvalidArgument
+			}
+			arg0, err := arg.String(0)
+			if err != nil {
+				return nil, err
+			}
+			HyperAddress, err := common.ParseAddress(arg0)
+			if err != nil {
+				return nil, err
+			}
+			arg1, err := arg.String(1)
+			if err != nil {
+				return nil, err
+			}
+			StakingAddress, err := common.ParseAddress(arg1)
+			if err != nil {
+				return nil, err
+			}
+			loader := cn.NewLoaderWrapper(p.ID())
+			return p.GetStakingAmount(loader, HyperAddress, StakingAddress), nil
+		})
+		s.Set("userAutoStaking", func(ID interface{}, arg *apiserver.Argument) (interface{}, error) {
+			if arg.Len() != 2 {
+				return nil, apiserver.ErrInvalidArgument
+			}
+			arg0, err := arg.String(0)
+			if err != nil {
+				return nil, err
+			}
+			HyperAddress, err := common.ParseAddress(arg0)
+			if err != nil {
+				return nil, err
+			}
+			arg1, err := arg.String(1)
+			if err != nil {
+				return nil, err
+			}
+			StakingAddress, err := common.ParseAddress(arg1)
+			if err != nil {
+				return nil, err
+			}
+			loader := cn.NewLoaderWrapper(p.ID())
+			return p.GetUserAutoStaking(loader, HyperAddress, StakingAddress), nil
+		})
+		s.Set("unstakingAmountMap", func(ID interface{}, arg *apiserver.Argument) (interface{}, error) {
+			if arg.Len() != 2 {
+				return nil, apiserver.ErrInvalidArgument
+			}
+			arg0, err := arg.String(0)
+			if err != nil {
+				return nil, err
+			}
+			StakingAddress, err := common.ParseAddress(arg0)
+			if err != nil {
+				return nil, err
+			}
+			UnstakedHeight, err := arg.Uint32(1)
+			if err != nil {
+				return nil, err
+			}
+			loader := cn.NewLoaderWrapper(p.ID())
+			return p.GetUnstakingAmountMap(loader, StakingAddress, UnstakedHeight)
+		})
+		s.Set("pendingUnstakingMap", func(ID interface{}, arg *apiserver.Argument) (interface{}, error) {
+			if arg.Len() != 1 {
+				return nil, apiserver.ErrInvalidArgument
+			}
+			arg0, err := arg.String(0)
+			if err != nil {
+				return nil, err
+			}
+			StakingAddress, err := common.ParseAddress(arg0)
+			if err != nil {
+				return nil, err
+			}
+			loader := cn.NewLoaderWrapper(p.ID())
+			return p.GetPendingUnstakingMap(loader, StakingAddress)
+		})
+		s.Set("estimateReward", func(ID interface{}, arg *apiserver.Argument) (interface{}, error) {
+			loader := cn.NewLoaderWrapper(p.ID())
+			return p.EstimateReward(loader)
+		})
+		s.Set("estimateStakingReward", func(ID interface{}, arg *apiserver.Argument) (interface{}, error) {
+			if arg.Len() != 2 {
+				return nil, apiserver.ErrInvalidArgument
+			}
+			arg0, err := arg.String(0)
+			if err != nil {
+				return nil, err
+			}
+			HyperAddress, err := common.ParseAddress(arg0)
+			if err != nil {
+				return nil, err
+			}
+			arg1, err := arg.String(1)
+			if err != nil {
+				return nil, err
+			}
+			StakingAddress, err := common.ParseAddress(arg1)
+			if err != nil {
+				return nil, err
+			}
+			loader := cn.NewLoaderWrapper(p.ID())
+			return p.EstimateStakingReward(loader, HyperAddress, StakingAddress)
+		})
+	}
 	return nil
 }
 
@@ -146,7 +317,9 @@ func (p *Formulator) InitStakingMap(ctw *types.ContextWrapper, HyperAddresses []
 
 // OnLoadChain called when the chain loaded
 func (p *Formulator) OnLoadChain(loader types.LoaderWrapper) error {
-	p.admin.AdminAddress(loader, p.Name())
+	if _, err := p.admin.AdminAddress(loader, p.Name()); err != nil {
+		return err
+	}
 	if bs := loader.ProcessData(tagRewardPolicy); len(bs) == 0 {
 		return ErrRewardPolicyShouldBeSetupInApplication
 	}
@@ -167,6 +340,12 @@ func (p *Formulator) OnLoadChain(loader types.LoaderWrapper) error {
 
 // BeforeExecuteTransactions called before processes transactions of the block
 func (p *Formulator) BeforeExecuteTransactions(ctw *types.ContextWrapper) error {
+	if err := p.activateConsensusPolicy(ctw); err != nil {
+		return err
+	}
+	if err := p.backfillUnstakingHeights(ctw); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -252,110 +431,26 @@ func (p *Formulator) AfterExecuteTransactions(b *types.Block, ctw *types.Context
 					RewardPowerSum = RewardPowerSum.Add(am)
 					RewardPowerMap[GenAddress] = am
 
-					PrevAmountMap := types.NewAddressAmountMap()
-					if bs := ctw.AccountData(frAcc.Address(), tagStakingAmountMap); len(bs) > 0 {
-						if err := encoding.Unmarshal(bs, &PrevAmountMap); err != nil {
-							return err
-						}
-					}
-					AmountMap, err := p.GetStakingAmountMap(ctw, frAcc.Address())
+					StackReward, _ := StackRewardMap.Get(frAcc.Address())
+					sp, err := p.computeStakingPower(ctw, policy, frAcc.Address(), GenCount, StackReward)
 					if err != nil {
 						return err
 					}
-					CurrentAmountMap := types.NewAddressAmountMap()
-					CrossAmountMap := map[common.Address]*amount.Amount{}
-					for StakingAddress, StakingAmount := range AmountMap {
-						if has, err := ctw.HasAccount(StakingAddress); err != nil {
-							if err == types.ErrDeletedAccount {
-								p.subStakingAmount(ctw, frAcc.Address(), StakingAddress, StakingAmount)
-							} else {
-								return err
-							}
-						} else if !has {
-							p.subStakingAmount(ctw, frAcc.Address(), StakingAddress, StakingAmount)
-						} else {
-							CurrentAmountMap.Put(StakingAddress, StakingAmount)
-							if PrevStakingAmount, has := PrevAmountMap.Get(StakingAddress); has {
-								if !PrevStakingAmount.IsZero() && !StakingAmount.IsZero() {
-									if StakingAmount.Less(PrevStakingAmount) {
-										CrossAmountMap[StakingAddress] = StakingAmount
-									} else {
-										CrossAmountMap[StakingAddress] = PrevStakingAmount
-									}
-								}
-							}
-						}
+					for StakingAddress, StakingAmount := range sp.RemovedAmountMap {
+						p.subStakingAmount(ctw, frAcc.Address(), StakingAddress, StakingAmount)
 					}
-					if bs, err := encoding.Marshal(CurrentAmountMap); err != nil {
+					if bs, err := encoding.Marshal(sp.CurrentAmountMap); err != nil {
 						return err
 					} else {
 						ctw.SetAccountData(frAcc.Address(), tagStakingAmountMap, bs)
 					}
-
-					StakingRewardPower := amount.NewCoinAmount(0, 0)
-					StakingPowerMap := types.NewAddressAmountMap()
-					if bs := ctw.AccountData(frAcc.Address(), tagStakingPowerMap); len(bs) > 0 {
-						if err := encoding.Unmarshal(bs, &StakingPowerMap); err != nil {
-							return err
-						}
-					}
-					for StakingAddress, StakingAmount := range CrossAmountMap {
-						if sm, has := StakingPowerMap.Get(StakingAddress); has {
-							StakingPowerMap.Put(StakingAddress, sm.Add(StakingAmount))
-						} else {
-							StakingPowerMap.Put(StakingAddress, StakingAmount)
-						}
-						StakingRewardPower = StakingRewardPower.Add(StakingAmount.MulC(int64(GenCount)).MulC(int64(policy.StakingEfficiency1000)).DivC(1000))
-					}
-
-					StackReward, has := StackRewardMap.Get(frAcc.Address())
-					if has {
-						StakingPowerSum := amount.NewCoinAmount(0, 0)
-						Deleteds := []common.Address{}
-						var inErr error
-						StakingPowerMap.EachAll(func(StakingAddress common.Address, StakingPower *amount.Amount) bool {
-							if has, err := ctw.HasAccount(StakingAddress); err != nil {
-								if err == types.ErrDeletedAccount {
-									Deleteds = append(Deleteds, StakingAddress)
-								} else {
-									inErr = err
-									return false
-								}
-							} else if !has {
-								Deleteds = append(Deleteds, StakingAddress)
-							} else {
-								StakingPowerSum = StakingPowerSum.Add(StakingPower)
-							}
-							return true
-						})
-						if inErr != nil {
-							return inErr
-						}
-						for _, StakingAddress := range Deleteds {
-							StakingPowerMap.Delete(StakingAddress)
-						}
-						if !StakingPowerSum.IsZero() {
-							var inErr error
-							Ratio := StackReward.Mul(amount.COIN).Div(StakingPowerSum)
-							StakingPowerMap.EachAll(func(StakingAddress common.Address, StakingPower *amount.Amount) bool {
-								StackStakingAmount := StakingPower.Mul(Ratio).Div(amount.COIN)
-								StakingPowerMap.Put(StakingAddress, StakingPower.Add(StackStakingAmount))
-								StakingRewardPower = StakingRewardPower.Add(StackStakingAmount.MulC(int64(GenCount)).MulC(int64(policy.StakingEfficiency1000)).DivC(1000))
-								return true
-							})
-							if inErr != nil {
-								return inErr
-							}
-						}
-					}
-
-					if bs, err := encoding.Marshal(StakingPowerMap); err != nil {
+					if bs, err := encoding.Marshal(sp.StakingPowerMap); err != nil {
 						return err
 					} else {
 						ctw.SetAccountData(frAcc.Address(), tagStakingPowerMap, bs)
 					}
-					StakingRewardPowerMap[GenAddress] = StakingRewardPower
-					RewardPowerSum = RewardPowerSum.Add(StakingRewardPower)
+					StakingRewardPowerMap[GenAddress] = sp.RewardPower
+					RewardPowerSum = RewardPowerSum.Add(sp.RewardPower)
 				default:
 					return types.ErrInvalidAccountType
 				}
@@ -417,72 +512,33 @@ func (p *Formulator) AfterExecuteTransactions(b *types.Block, ctw *types.Context
 				ev.RemoveStacked(frAcc.Address())
 				lastStakingPaidHeight := p.getLastStakingPaidHeight(ctw, frAcc.Address())
 				if ctw.TargetHeight() >= lastStakingPaidHeight+policy.PayRewardEveryBlocks*frAcc.Policy.PayOutInterval {
-					StakingPowerMap := types.NewAddressAmountMap()
-					if bs := ctw.AccountData(frAcc.Address(), tagStakingPowerMap); len(bs) > 0 {
-						if err := encoding.Unmarshal(bs, &StakingPowerMap); err != nil {
-							return err
-						}
-					}
-
-					StakingPowerSum := amount.NewCoinAmount(0, 0)
-					var inErr error
-					Deleteds := []common.Address{}
-					StakingPowerMap.EachAll(func(StakingAddress common.Address, StakingPower *amount.Amount) bool {
-						if has, err := ctw.HasAccount(StakingAddress); err != nil {
-							if err == types.ErrDeletedAccount {
-								Deleteds = append(Deleteds, StakingAddress)
-							} else {
-								inErr = err
-								return false
-							}
-						} else if !has {
-							Deleteds = append(Deleteds, StakingAddress)
-						} else {
-							StakingPowerSum = StakingPowerSum.Add(StakingPower)
-						}
-						return true
-					})
-					for _, StakingAddress := range Deleteds {
-						StakingPowerMap.Delete(StakingAddress)
+					StakingPowerMap, err := p.getStakingPowerMap(ctw, frAcc.Address())
+					if err != nil {
+						return err
 					}
-					if inErr != nil {
-						return inErr
+					Rewards, CommissionSum, err := p.divideStackReward(ctw, frAcc, StakingPowerMap, StackReward)
+					if err != nil {
+						return err
 					}
-					if !StakingPowerSum.IsZero() {
-						CommissionSum := amount.NewCoinAmount(0, 0)
-						var inErr error
-						Ratio := StackReward.Mul(amount.COIN).Div(StakingPowerSum)
-						StakingPowerMap.EachAll(func(StakingAddress common.Address, StakingPower *amount.Amount) bool {
-							RewardAmount := StakingPower.Mul(Ratio).Div(amount.COIN)
-							if frAcc.Policy.CommissionRatio1000 > 0 {
-								Commission := RewardAmount.MulC(int64(frAcc.Policy.CommissionRatio1000)).DivC(1000)
-								CommissionSum = CommissionSum.Add(Commission)
-								RewardAmount = RewardAmount.Sub(Commission)
-							}
-							if !RewardAmount.IsZero() {
-								if p.GetUserAutoStaking(ctw, frAcc.Address(), StakingAddress) {
-									p.AddStakingAmount(ctw, frAcc.Address(), StakingAddress, RewardAmount)
-									ev.AddStaked(frAcc.Address(), StakingAddress, RewardAmount)
-								} else {
-									if err := p.vault.AddBalance(ctw, StakingAddress, RewardAmount); err != nil {
-										inErr = err
-										return false
-									}
-									ev.AddStakeReward(frAcc.Address(), StakingAddress, RewardAmount)
-								}
-							}
-							return true
-						})
-						if inErr != nil {
-							return inErr
+					for _, sr := range Rewards {
+						if sr.RewardAmount.IsZero() {
+							continue
 						}
-
-						if !CommissionSum.IsZero() {
-							if err := p.vault.AddBalance(ctw, frAcc.Address(), CommissionSum); err != nil {
+						if p.GetUserAutoStaking(ctw, frAcc.Address(), sr.StakingAddress) {
+							p.AddStakingAmount(ctw, frAcc.Address(), sr.StakingAddress, sr.RewardAmount)
+							ev.AddStaked(frAcc.Address(), sr.StakingAddress, sr.RewardAmount)
+						} else {
+							if err := p.vault.AddBalance(ctw, sr.StakingAddress, sr.RewardAmount); err != nil {
 								return err
 							}
-							ev.AddCommission(frAcc.Address(), CommissionSum)
+							ev.AddStakeReward(frAcc.Address(), sr.StakingAddress, sr.RewardAmount)
+						}
+					}
+					if !CommissionSum.IsZero() {
+						if err := p.vault.AddBalance(ctw, frAcc.Address(), CommissionSum); err != nil {
+							return err
 						}
+						ev.AddCommission(frAcc.Address(), CommissionSum)
 					}
 					ctw.SetAccountData(frAcc.Address(), tagStakingPowerMap, nil)
 
@@ -1,6 +1,8 @@
 package formulator
 
 import (
+	"time"
+
 	"github.com/fletaio/fleta/common"
 	"github.com/fletaio/fleta/common/amount"
 	"github.com/fletaio/fleta/common/binutil"
@@ -306,6 +308,8 @@ func (p *Formulator) addUnstakingAmount(ctw *types.ContextWrapper, HyperAddr com
 		ctw.SetProcessData(toUnstakingAmountReverseKey(UnstakedHeight, Count), addr[:])
 		Count++
 		ctw.SetProcessData(toUnstakingAmountCountKey(UnstakedHeight), binutil.LittleEndian.Uint32ToBytes(Count))
+
+		p.addUnstakingHeight(ctw, addr, UnstakedHeight)
 	}
 	mp, err := p.GetUnstakingAmountMap(ctw, addr, UnstakedHeight)
 	if err != nil {
@@ -381,6 +385,8 @@ func (p *Formulator) removeUnstakingAmount(ctw *types.ContextWrapper, addr commo
 	} else {
 		ctw.SetProcessData(toUnstakingAmountCountKey(UnstakedHeight), binutil.LittleEndian.Uint32ToBytes(Count))
 	}
+
+	p.removeUnstakingHeight(ctw, addr, UnstakedHeight)
 	return nil
 }
 
@@ -400,12 +406,127 @@ func (p *Formulator) flushUnstakingAmountMap(ctw *types.ContextWrapper, RevokeHe
 			ctw.SetProcessData(toUnstakingAmountKey(RevokeHeight, addr), nil)
 			ctw.SetProcessData(toUnstakingAmountNumberKey(RevokeHeight, addr), nil)
 			ctw.SetProcessData(toUnstakingAmountReverseKey(RevokeHeight, i), nil)
+
+			p.removeUnstakingHeight(ctw, addr, RevokeHeight)
 		}
 		ctw.SetProcessData(toUnstakingAmountCountKey(RevokeHeight), nil)
 	}
 	return UnstakingAmountMap, nil
 }
 
+// GetUnstakingHeights returns unstaked heights of pending unstakings of the address
+func (p *Formulator) GetUnstakingHeights(loader types.Loader, addr common.Address) []uint32 {
+	lw := types.NewLoaderWrapper(p.pid, loader)
+
+	heights := []uint32{}
+	if bs := lw.AccountData(addr, tagUnstakingHeightCount); len(bs) > 0 {
+		Count := binutil.LittleEndian.Uint32(bs)
+		for i := uint32(0); i < Count; i++ {
+			heights = append(heights, binutil.LittleEndian.Uint32(lw.AccountData(addr, toUnstakingHeightReverseKey(i))))
+		}
+	}
+	return heights
+}
+
+// GetPendingUnstakingMap returns amount maps of pending unstakings of the address by unstaked heights
+func (p *Formulator) GetPendingUnstakingMap(loader types.Loader, addr common.Address) (map[uint32]*types.AddressAmountMap, error) {
+	lw := types.NewLoaderWrapper(p.pid, loader)
+
+	UnstakingMap := map[uint32]*types.AddressAmountMap{}
+	for _, UnstakedHeight := range p.GetUnstakingHeights(lw, addr) {
+		mp, err := p.GetUnstakingAmountMap(lw, addr, UnstakedHeight)
+		if err != nil {
+			return nil, err
+		}
+		UnstakingMap[UnstakedHeight] = mp
+	}
+	return UnstakingMap, nil
+}
+
+func (p *Formulator) addUnstakingHeight(ctw *types.ContextWrapper, addr common.Address, UnstakedHeight uint32) {
+	if ns := ctw.AccountData(addr, toUnstakingHeightKey(UnstakedHeight)); len(ns) > 0 {
+		return
+	}
+	var Count uint32
+	if bs := ctw.AccountData(addr, tagUnstakingHeightCount); len(bs) > 0 {
+		Count = binutil.LittleEndian.Uint32(bs)
+	}
+	ctw.SetAccountData(addr, toUnstakingHeightKey(UnstakedHeight), binutil.LittleEndian.Uint32ToBytes(Count))
+	ctw.SetAccountData(addr, toUnstakingHeightReverseKey(Count), binutil.LittleEndian.Uint32ToBytes(UnstakedHeight))
+	Count++
+	ctw.SetAccountData(addr, tagUnstakingHeightCount, binutil.LittleEndian.Uint32ToBytes(Count))
+}
+
+func (p *Formulator) removeUnstakingHeight(ctw *types.ContextWrapper, addr common.Address, UnstakedHeight uint32) {
+	ns := ctw.AccountData(addr, toUnstakingHeightKey(UnstakedHeight))
+	if len(ns) == 0 {
+		return
+	}
+	var Count uint32
+	if bs := ctw.AccountData(addr, tagUnstakingHeightCount); len(bs) > 0 {
+		Count = binutil.LittleEndian.Uint32(bs)
+	}
+	Number := binutil.LittleEndian.Uint32(ns)
+	if Number != Count-1 {
+		swapHeight := ctw.AccountData(addr, toUnstakingHeightReverseKey(Count-1))
+		ctw.SetAccountData(addr, toUnstakingHeightReverseKey(Number), swapHeight)
+		ctw.SetAccountData(addr, toUnstakingHeightKey(binutil.LittleEndian.Uint32(swapHeight)), binutil.LittleEndian.Uint32ToBytes(Number))
+	}
+	ctw.SetAccountData(addr, toUnstakingHeightKey(UnstakedHeight), nil)
+	ctw.SetAccountData(addr, toUnstakingHeightReverseKey(Count-1), nil)
+	Count--
+	if Count == 0 {
+		ctw.SetAccountData(addr, tagUnstakingHeightCount, nil)
+	} else {
+		ctw.SetAccountData(addr, tagUnstakingHeightCount, binutil.LittleEndian.Uint32ToBytes(Count))
+	}
+}
+
+// UnstakingBackfillBatch is the number of unstaked heights scanned by a block to backfill the unstaking height index
+const UnstakingBackfillBatch = 10000
+
+// backfillUnstakingHeights adds pending unstakings queued before the unstaking height index to the index
+// unstaked heights are in the unlock period of the hyper policy so it scans UnstakingBackfillBatch heights of the period per block from the cursor
+// the cursor stores the next height to scan and the last height to scan
+func (p *Formulator) backfillUnstakingHeights(ctw *types.ContextWrapper) error {
+	From := ctw.TargetHeight()
+	var To uint32
+	if bs := ctw.ProcessData(tagUnstakingHeightBackfill); len(bs) == 8 {
+		From = binutil.LittleEndian.Uint32(bs[:4])
+		To = binutil.LittleEndian.Uint32(bs[4:])
+		if From > To {
+			return nil
+		}
+	} else {
+		policy, err := p.GetHyperPolicy(ctw)
+		if err != nil {
+			return err
+		}
+		To = ctw.TargetHeight() + policy.StakingUnlockRequiredBlocks
+	}
+	// unstakings under the target height are already unlocked
+	if From < ctw.TargetHeight() {
+		From = ctw.TargetHeight()
+	}
+	End := From + UnstakingBackfillBatch - 1
+	if End > To {
+		End = To
+	}
+	ctw.SetProcessData(tagUnstakingHeightBackfill, append(binutil.LittleEndian.Uint32ToBytes(End+1), binutil.LittleEndian.Uint32ToBytes(To)...))
+
+	for UnstakedHeight := From; UnstakedHeight <= End; UnstakedHeight++ {
+		if bs := ctw.ProcessData(toUnstakingAmountCountKey(UnstakedHeight)); len(bs) > 0 {
+			Count := binutil.LittleEndian.Uint32(bs)
+			for i := uint32(0); i < Count; i++ {
+				var addr common.Address
+				copy(addr[:], ctw.ProcessData(toUnstakingAmountReverseKey(UnstakedHeight, i)))
+				p.addUnstakingHeight(ctw, addr, UnstakedHeight)
+			}
+		}
+	}
+	return nil
+}
+
 // GetRewardPolicy returns the reward policy
 func (p *Formulator) GetRewardPolicy(loader types.Loader) (*RewardPolicy, error) {
 	lw := types.NewLoaderWrapper(p.pid, loader)
@@ -493,6 +614,77 @@ func (p *Formulator) GetMiningFeePolicy(loader types.Loader) (*MiningFeePolicy,
 	return policy, nil
 }
 
+// GetConsensusPolicy returns the consensus policy
+func (p *Formulator) GetConsensusPolicy(loader types.Loader) (*ConsensusPolicy, error) {
+	lw := types.NewLoaderWrapper(p.pid, loader)
+
+	bs := lw.ProcessData(tagConsensusPolicy)
+	if len(bs) == 0 {
+		return nil, ErrNotExistConsensusPolicy
+	}
+
+	policy := &ConsensusPolicy{}
+	if err := encoding.Unmarshal(bs, &policy); err != nil {
+		return nil, err
+	}
+	return policy, nil
+}
+
+// GetPendingConsensusPolicy returns the consensus policy that waits the activate height
+func (p *Formulator) GetPendingConsensusPolicy(loader types.Loader) (*ConsensusPolicy, uint32, error) {
+	lw := types.NewLoaderWrapper(p.pid, loader)
+
+	bs := lw.ProcessData(tagPendingConsensusPolicy)
+	if len(bs) < 4 {
+		return nil, 0, ErrNotExistConsensusPolicy
+	}
+	ActivateHeight := binutil.LittleEndian.Uint32(bs)
+
+	policy := &ConsensusPolicy{}
+	if err := encoding.Unmarshal(bs[4:], &policy); err != nil {
+		return nil, 0, err
+	}
+	return policy, ActivateHeight, nil
+}
+
+func (p *Formulator) setPendingConsensusPolicy(ctw *types.ContextWrapper, ActivateHeight uint32, policy *ConsensusPolicy) error {
+	if bs, err := encoding.Marshal(policy); err != nil {
+		return err
+	} else {
+		ctw.SetProcessData(tagPendingConsensusPolicy, append(binutil.LittleEndian.Uint32ToBytes(ActivateHeight), bs...))
+	}
+	return nil
+}
+
+func (p *Formulator) activateConsensusPolicy(ctw *types.ContextWrapper) error {
+	policy, ActivateHeight, err := p.GetPendingConsensusPolicy(ctw)
+	if err != nil {
+		if err == ErrNotExistConsensusPolicy {
+			return nil
+		}
+		return err
+	}
+	if ActivateHeight > ctw.TargetHeight() {
+		return nil
+	}
+	if bs, err := encoding.Marshal(policy); err != nil {
+		return err
+	} else {
+		ctw.SetProcessData(tagConsensusPolicy, bs)
+	}
+	ctw.SetProcessData(tagPendingConsensusPolicy, nil)
+	return nil
+}
+
+// ConsensusParameters returns the activated consensus parameters of the chain
+func (p *Formulator) ConsensusParameters(loader types.Loader) (uint32, time.Duration, time.Duration, bool) {
+	policy, err := p.GetConsensusPolicy(loader)
+	if err != nil {
+		return 0, 0, 0, false
+	}
+	return policy.MaxBlocksPerFormulator, time.Duration(policy.BlockIntervalMS) * time.Millisecond, time.Duration(policy.RoundTimeoutMS) * time.Millisecond, true
+}
+
 // IsRewardBaseUpgrade returns reward base upgrade on/off
 func (p *Formulator) IsRewardBaseUpgrade(loader types.Loader) bool {
 	lw := types.NewLoaderWrapper(p.pid, loader)
@@ -564,18 +756,17 @@ func (p *Formulator) revokeFormulator(ctw *types.ContextWrapper, FormulatorAddr
 }
 
 func (p *Formulator) processFormulatorMiningReward(ctw *types.ContextWrapper, ev *RewardEvent, RewardAddress common.Address, RewardAmount *amount.Amount) error {
-	miningFeePolicy, err := p.GetMiningFeePolicy(ctw)
+	miningFeePolicy, fee, err := p.miningFee(ctw, RewardAmount)
 	if err != nil {
-		if err != ErrNotExistMiningFeePolicy {
-			return err
-		}
+		return err
+	}
+	if miningFeePolicy == nil {
 		if err := p.vault.AddBalance(ctw, RewardAddress, RewardAmount); err != nil {
 			return err
 		}
 		ev.AddReward(RewardAddress, RewardAmount)
 		return nil
 	} else {
-		fee := RewardAmount.MulC(int64(miningFeePolicy.MiningFee1000)).DivC(1000)
 		if err := p.vault.AddBalance(ctw, miningFeePolicy.MiningFeeAddress, fee); err != nil {
 			return err
 		}
@@ -588,3 +779,209 @@ func (p *Formulator) processFormulatorMiningReward(ctw *types.ContextWrapper, ev
 		return nil
 	}
 }
+
+// miningFee returns the mining fee policy and the mining fee of the reward of the formulator
+// the policy is nil when the mining fee policy is not exist
+func (p *Formulator) miningFee(lw types.LoaderWrapper, RewardAmount *amount.Amount) (*MiningFeePolicy, *amount.Amount, error) {
+	miningFeePolicy, err := p.GetMiningFeePolicy(lw)
+	if err != nil {
+		if err != ErrNotExistMiningFeePolicy {
+			return nil, nil, err
+		}
+		return nil, amount.NewCoinAmount(0, 0), nil
+	}
+	return miningFeePolicy, RewardAmount.MulC(int64(miningFeePolicy.MiningFee1000)).DivC(1000), nil
+}
+
+// hyperStakingPower is the staking power of the hyper formulator at the payout
+type hyperStakingPower struct {
+	CurrentAmountMap *types.AddressAmountMap
+	RemovedAmountMap map[common.Address]*amount.Amount
+	StakingPowerMap  *types.AddressAmountMap
+	RewardPower      *amount.Amount
+}
+
+func (p *Formulator) getStakingPowerMap(lw types.LoaderWrapper, HyperAddress common.Address) (*types.AddressAmountMap, error) {
+	StakingPowerMap := types.NewAddressAmountMap()
+	if bs := lw.AccountData(HyperAddress, tagStakingPowerMap); len(bs) > 0 {
+		if err := encoding.Unmarshal(bs, &StakingPowerMap); err != nil {
+			return nil, err
+		}
+	}
+	return StakingPowerMap, nil
+}
+
+// computeStakingPower computes the staking power of the hyper formulator at the payout
+// the staking address gets the power of the smaller one of the staking amounts of the last and the current payout
+// and the stacked reward is added to powers of staking addresses until it is paid out
+func (p *Formulator) computeStakingPower(lw types.LoaderWrapper, policy *RewardPolicy, HyperAddress common.Address, GenCount uint32, StackReward *amount.Amount) (*hyperStakingPower, error) {
+	PrevAmountMap := types.NewAddressAmountMap()
+	if bs := lw.AccountData(HyperAddress, tagStakingAmountMap); len(bs) > 0 {
+		if err := encoding.Unmarshal(bs, &PrevAmountMap); err != nil {
+			return nil, err
+		}
+	}
+	AmountMap, err := p.GetStakingAmountMap(lw, HyperAddress)
+	if err != nil {
+		return nil, err
+	}
+	sp := &hyperStakingPower{
+		CurrentAmountMap: types.NewAddressAmountMap(),
+		RemovedAmountMap: map[common.Address]*amount.Amount{},
+		RewardPower:      amount.NewCoinAmount(0, 0),
+	}
+	CrossAmountMap := map[common.Address]*amount.Amount{}
+	for StakingAddress, StakingAmount := range AmountMap {
+		if has, err := lw.HasAccount(StakingAddress); err != nil {
+			if err == types.ErrDeletedAccount {
+				sp.RemovedAmountMap[StakingAddress] = StakingAmount
+			} else {
+				return nil, err
+			}
+		} else if !has {
+			sp.RemovedAmountMap[StakingAddress] = StakingAmount
+		} else {
+			sp.CurrentAmountMap.Put(StakingAddress, StakingAmount)
+			if PrevStakingAmount, has := PrevAmountMap.Get(StakingAddress); has {
+				if !PrevStakingAmount.IsZero() && !StakingAmount.IsZero() {
+					if StakingAmount.Less(PrevStakingAmount) {
+						CrossAmountMap[StakingAddress] = StakingAmount
+					} else {
+						CrossAmountMap[StakingAddress] = PrevStakingAmount
+					}
+				}
+			}
+		}
+	}
+
+	StakingPowerMap, err := p.getStakingPowerMap(lw, HyperAddress)
+	if err != nil {
+		return nil, err
+	}
+	for StakingAddress, StakingAmount := range CrossAmountMap {
+		if sm, has := StakingPowerMap.Get(StakingAddress); has {
+			StakingPowerMap.Put(StakingAddress, sm.Add(StakingAmount))
+		} else {
+			StakingPowerMap.Put(StakingAddress, StakingAmount)
+		}
+		sp.RewardPower = sp.RewardPower.Add(StakingAmount.MulC(int64(GenCount)).MulC(int64(policy.StakingEfficiency1000)).DivC(1000))
+	}
+
+	if StackReward != nil {
+		StakingPowerSum, err := p.sumStakingPower(lw, StakingPowerMap)
+		if err != nil {
+			return nil, err
+		}
+		if !StakingPowerSum.IsZero() {
+			Ratio := StackReward.Mul(amount.COIN).Div(StakingPowerSum)
+			StakingPowerMap.EachAll(func(StakingAddress common.Address, StakingPower *amount.Amount) bool {
+				StackStakingAmount := StakingPower.Mul(Ratio).Div(amount.COIN)
+				StakingPowerMap.Put(StakingAddress, StakingPower.Add(StackStakingAmount))
+				sp.RewardPower = sp.RewardPower.Add(StackStakingAmount.MulC(int64(GenCount)).MulC(int64(policy.StakingEfficiency1000)).DivC(1000))
+				return true
+			})
+		}
+	}
+	sp.StakingPowerMap = StakingPowerMap
+	return sp, nil
+}
+
+// sumStakingPower removes powers of deleted staking addresses from the map and returns the sum of remained powers
+func (p *Formulator) sumStakingPower(lw types.LoaderWrapper, StakingPowerMap *types.AddressAmountMap) (*amount.Amount, error) {
+	StakingPowerSum := amount.NewCoinAmount(0, 0)
+	Deleteds := []common.Address{}
+	var inErr error
+	StakingPowerMap.EachAll(func(StakingAddress common.Address, StakingPower *amount.Amount) bool {
+		if has, err := lw.HasAccount(StakingAddress); err != nil {
+			if err == types.ErrDeletedAccount {
+				Deleteds = append(Deleteds, StakingAddress)
+			} else {
+				inErr = err
+				return false
+			}
+		} else if !has {
+			Deleteds = append(Deleteds, StakingAddress)
+		} else {
+			StakingPowerSum = StakingPowerSum.Add(StakingPower)
+		}
+		return true
+	})
+	if inErr != nil {
+		return nil, inErr
+	}
+	for _, StakingAddress := range Deleteds {
+		StakingPowerMap.Delete(StakingAddress)
+	}
+	return StakingPowerSum, nil
+}
+
+// stakingReward is the reward of the staking address after the commission
+type stakingReward struct {
+	StakingAddress common.Address
+	RewardAmount   *amount.Amount
+}
+
+// divideStackReward divides the stacked reward of the hyper formulator by staking powers
+// it returns rewards of staking addresses in the order of the power map and the commission of the hyper formulator
+func (p *Formulator) divideStackReward(lw types.LoaderWrapper, frAcc *FormulatorAccount, StakingPowerMap *types.AddressAmountMap, StackReward *amount.Amount) ([]*stakingReward, *amount.Amount, error) {
+	StakingPowerSum, err := p.sumStakingPower(lw, StakingPowerMap)
+	if err != nil {
+		return nil, nil, err
+	}
+	Rewards := []*stakingReward{}
+	CommissionSum := amount.NewCoinAmount(0, 0)
+	if !StakingPowerSum.IsZero() {
+		Ratio := StackReward.Mul(amount.COIN).Div(StakingPowerSum)
+		StakingPowerMap.EachAll(func(StakingAddress common.Address, StakingPower *amount.Amount) bool {
+			RewardAmount := StakingPower.Mul(Ratio).Div(amount.COIN)
+			if frAcc.Policy.CommissionRatio1000 > 0 {
+				Commission := RewardAmount.MulC(int64(frAcc.Policy.CommissionRatio1000)).DivC(1000)
+				CommissionSum = CommissionSum.Add(Commission)
+				RewardAmount = RewardAmount.Sub(Commission)
+			}
+			Rewards = append(Rewards, &stakingReward{
+				StakingAddress: StakingAddress,
+				RewardAmount:   RewardAmount,
+			})
+			return true
+		})
+	}
+	return Rewards, CommissionSum, nil
+}
+
+// GetRedelegatedHeight returns the height of the last redelegation of the address
+func (p *Formulator) GetRedelegatedHeight(loader types.Loader, StakingAddress common.Address) uint32 {
+	lw := types.NewLoaderWrapper(p.pid, loader)
+
+	if bs := lw.AccountData(StakingAddress, tagRedelegatedHeight); len(bs) > 0 {
+		return binutil.LittleEndian.Uint32(bs)
+	} else {
+		return 0
+	}
+}
+
+func (p *Formulator) setRedelegatedHeight(ctw *types.ContextWrapper, StakingAddress common.Address, Height uint32) {
+	ctw.SetAccountData(StakingAddress, tagRedelegatedHeight, binutil.LittleEndian.Uint32ToBytes(Height))
+}
+
+// addStakingSnapshot adds the amount to the staking amount snapshot of the last payout of the hyper formulator
+// the moved amount is counted as staked since the last payout so the power of the current period is not lost
+func (p *Formulator) addStakingSnapshot(ctw *types.ContextWrapper, HyperAddress common.Address, StakingAddress common.Address, am *amount.Amount) error {
+	PrevAmountMap := types.NewAddressAmountMap()
+	if bs := ctw.AccountData(HyperAddress, tagStakingAmountMap); len(bs) > 0 {
+		if err := encoding.Unmarshal(bs, &PrevAmountMap); err != nil {
+			return err
+		}
+	}
+	if PrevStakingAmount, has := PrevAmountMap.Get(StakingAddress); has {
+		PrevAmountMap.Put(StakingAddress, PrevStakingAmount.Add(am))
+	} else {
+		PrevAmountMap.Put(StakingAddress, am)
+	}
+	if bs, err := encoding.Marshal(PrevAmountMap); err != nil {
+		return err
+	} else {
+		ctw.SetAccountData(HyperAddress, tagStakingAmountMap, bs)
+	}
+	return nil
+}
@@ -312,3 +312,38 @@ func (pc *MiningFeePolicy) MarshalJSON() ([]byte, error) {
 	buffer.WriteString(`}`)
 	return buffer.Bytes(), nil
 }
+
+// ConsensusPolicy defines a consensus parameter set applied from the activation height
+type ConsensusPolicy struct {
+	MaxBlocksPerFormulator uint32
+	BlockIntervalMS        uint32
+	RoundTimeoutMS         uint32
+}
+
+// MarshalJSON is a marshaler function
+func (pc *ConsensusPolicy) MarshalJSON() ([]byte, error) {
+	var buffer bytes.Buffer
+	buffer.WriteString(`{`)
+	buffer.WriteString(`"max_blocks_per_formulator":`)
+	if bs, err := json.Marshal(pc.MaxBlocksPerFormulator); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"block_interval_ms":`)
+	if bs, err := json.Marshal(pc.BlockIntervalMS); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"round_timeout_ms":`)
+	if bs, err := json.Marshal(pc.RoundTimeoutMS); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`}`)
+	return buffer.Bytes(), nil
+}
@@ -0,0 +1,301 @@
+package formulator
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/fletaio/fleta/common"
+	"github.com/fletaio/fleta/common/amount"
+	"github.com/fletaio/fleta/common/binutil"
+	"github.com/fletaio/fleta/core/types"
+	"github.com/fletaio/fleta/encoding"
+)
+
+// RewardEstimate is the projected result of the next reward payout
+type RewardEstimate struct {
+	Height           uint32
+	TotalReward      *amount.Amount
+	GenBlockMap      *types.AddressUint32Map
+	RewardMap        *types.AddressAmountMap
+	StakingRewardMap *types.AddressAmountMap
+}
+
+// MarshalJSON is a marshaler function
+func (re *RewardEstimate) MarshalJSON() ([]byte, error) {
+	var buffer bytes.Buffer
+	buffer.WriteString(`{`)
+	buffer.WriteString(`"height":`)
+	if bs, err := json.Marshal(re.Height); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"total_reward":`)
+	if bs, err := re.TotalReward.MarshalJSON(); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"gen_block_map":`)
+	if bs, err := re.GenBlockMap.MarshalJSON(); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"reward_map":`)
+	if bs, err := re.RewardMap.MarshalJSON(); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"staking_reward_map":`)
+	if bs, err := re.StakingRewardMap.MarshalJSON(); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`}`)
+	return buffer.Bytes(), nil
+}
+
+// genCountMap returns gen counts since the last payout without flushing them
+func (p *Formulator) genCountMap(lw types.LoaderWrapper) map[common.Address]uint32 {
+	CountMap := map[common.Address]uint32{}
+	if bs := lw.ProcessData(tagGenCountCount); len(bs) > 0 {
+		Count := binutil.LittleEndian.Uint32(bs)
+		for i := uint32(0); i < Count; i++ {
+			var addr common.Address
+			copy(addr[:], lw.ProcessData(toGenCountReverseKey(i)))
+			CountMap[addr] = p.getGenCount(lw, addr)
+		}
+	}
+	return CountMap
+}
+
+// EstimateReward simulates the next payout from current gen counts and the reward policy
+// staking powers are computed as the payout does so blocks generated until the payout are not counted
+func (p *Formulator) EstimateReward(loader types.Loader) (*RewardEstimate, error) {
+	lw := types.NewLoaderWrapper(p.pid, loader)
+
+	re, _, err := p.estimateReward(lw)
+	if err != nil {
+		return nil, err
+	}
+	return re, nil
+}
+
+// estimateReward returns the estimate of the next payout and staking powers of hyper formulators at the payout
+func (p *Formulator) estimateReward(lw types.LoaderWrapper) (*RewardEstimate, map[common.Address]*hyperStakingPower, error) {
+	policy, err := p.GetRewardPolicy(lw)
+	if err != nil {
+		return nil, nil, err
+	}
+	lastPaidHeight := p.getLastPaidHeight(lw)
+	Height := lastPaidHeight + policy.PayRewardEveryBlocks
+	if Height < lw.TargetHeight() {
+		Height = lw.TargetHeight()
+	}
+
+	StackRewardMap := types.NewAddressAmountMap()
+	if bs := lw.ProcessData(tagStackRewardMap); len(bs) > 0 {
+		if err := encoding.Unmarshal(bs, &StackRewardMap); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	re := &RewardEstimate{
+		Height:           Height,
+		TotalReward:      policy.RewardPerBlock.MulC(int64(Height - lastPaidHeight)).Add(p.vault.CollectedFee(lw)),
+		GenBlockMap:      types.NewAddressUint32Map(),
+		RewardMap:        types.NewAddressAmountMap(),
+		StakingRewardMap: types.NewAddressAmountMap(),
+	}
+
+	RewardPowerSum := amount.NewCoinAmount(0, 0)
+	RewardPowerMap := map[common.Address]*amount.Amount{}
+	StakingPowerMap := map[common.Address]*hyperStakingPower{}
+	Hypers := map[common.Address]bool{}
+	for GenAddress, GenCount := range p.genCountMap(lw) {
+		re.GenBlockMap.Put(GenAddress, GenCount)
+
+		if has, err := lw.HasAccount(GenAddress); err != nil {
+			if err == types.ErrDeletedAccount {
+				continue
+			}
+			return nil, nil, err
+		} else if !has {
+			continue
+		}
+		acc, err := lw.Account(GenAddress)
+		if err != nil {
+			return nil, nil, err
+		}
+		frAcc, is := acc.(*FormulatorAccount)
+		if !is {
+			return nil, nil, types.ErrInvalidAccountType
+		}
+		if frAcc.IsRevoked {
+			continue
+		}
+		var Efficiency1000 uint32
+		switch frAcc.FormulatorType {
+		case AlphaFormulatorType:
+			Efficiency1000 = policy.AlphaEfficiency1000
+		case SigmaFormulatorType:
+			Efficiency1000 = policy.SigmaEfficiency1000
+		case OmegaFormulatorType:
+			Efficiency1000 = policy.OmegaEfficiency1000
+		case HyperFormulatorType:
+			Efficiency1000 = policy.HyperEfficiency1000
+			Hypers[GenAddress] = true
+
+			StackReward, _ := StackRewardMap.Get(GenAddress)
+			sp, err := p.computeStakingPower(lw, policy, GenAddress, GenCount, StackReward)
+			if err != nil {
+				return nil, nil, err
+			}
+			StakingPowerMap[GenAddress] = sp
+			RewardPowerSum = RewardPowerSum.Add(sp.RewardPower)
+		default:
+			return nil, nil, types.ErrInvalidAccountType
+		}
+		am := frAcc.Amount.MulC(int64(GenCount)).MulC(int64(Efficiency1000)).DivC(1000)
+		RewardPowerSum = RewardPowerSum.Add(am)
+		RewardPowerMap[GenAddress] = am
+	}
+
+	if !RewardPowerSum.IsZero() {
+		Ratio := re.TotalReward.Mul(amount.COIN).Div(RewardPowerSum)
+		for RewardAddress, RewardPower := range RewardPowerMap {
+			RewardAmount := RewardPower.Mul(Ratio).Div(amount.COIN)
+			if !Hypers[RewardAddress] {
+				miningFeePolicy, fee, err := p.miningFee(lw, RewardAmount)
+				if err != nil {
+					return nil, nil, err
+				}
+				if miningFeePolicy != nil {
+					re.addReward(miningFeePolicy.MiningFeeAddress, fee)
+					RewardAmount = RewardAmount.Sub(fee)
+				}
+			}
+			re.addReward(RewardAddress, RewardAmount)
+		}
+		for GenAddress, sp := range StakingPowerMap {
+			re.StakingRewardMap.Put(GenAddress, sp.RewardPower.Mul(Ratio).Div(amount.COIN))
+		}
+	}
+	return re, StakingPowerMap, nil
+}
+
+func (re *RewardEstimate) addReward(addr common.Address, am *amount.Amount) {
+	if sum, has := re.RewardMap.Get(addr); has {
+		re.RewardMap.Put(addr, sum.Add(am))
+	} else {
+		re.RewardMap.Put(addr, am)
+	}
+}
+
+// StakingRewardEstimate is the projected staking reward of the staking address at the hyper formulator
+type StakingRewardEstimate struct {
+	PayoutHeight uint32
+	RewardAmount *amount.Amount
+}
+
+// MarshalJSON is a marshaler function
+func (se *StakingRewardEstimate) MarshalJSON() ([]byte, error) {
+	var buffer bytes.Buffer
+	buffer.WriteString(`{`)
+	buffer.WriteString(`"payout_height":`)
+	if bs, err := json.Marshal(se.PayoutHeight); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"reward_amount":`)
+	if bs, err := se.RewardAmount.MarshalJSON(); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`}`)
+	return buffer.Bytes(), nil
+}
+
+// EstimateStakingReward returns the projected reward of the staking address at the hyper formulator after the commission
+// the reward is stacked until the payout interval of the hyper formulator
+// so it is the share of the reward stacked until the next payout and rewards of later payouts are not included
+func (p *Formulator) EstimateStakingReward(loader types.Loader, HyperAddress common.Address, StakingAddress common.Address) (*StakingRewardEstimate, error) {
+	lw := types.NewLoaderWrapper(p.pid, loader)
+
+	acc, err := lw.Account(HyperAddress)
+	if err != nil {
+		return nil, err
+	}
+	frAcc, is := acc.(*FormulatorAccount)
+	if !is || frAcc.FormulatorType != HyperFormulatorType {
+		return nil, types.ErrInvalidAccountType
+	}
+	policy, err := p.GetRewardPolicy(lw)
+	if err != nil {
+		return nil, err
+	}
+
+	re, StakingPowerMap, err := p.estimateReward(lw)
+	if err != nil {
+		return nil, err
+	}
+
+	PayoutHeight := re.Height
+	if policy.PayRewardEveryBlocks > 0 {
+		for PayoutHeight < p.getLastStakingPaidHeight(lw, HyperAddress)+policy.PayRewardEveryBlocks*frAcc.Policy.PayOutInterval {
+			PayoutHeight += policy.PayRewardEveryBlocks
+		}
+	}
+	se := &StakingRewardEstimate{
+		PayoutHeight: PayoutHeight,
+		RewardAmount: amount.NewCoinAmount(0, 0),
+	}
+
+	StackRewardMap := types.NewAddressAmountMap()
+	if bs := lw.ProcessData(tagStackRewardMap); len(bs) > 0 {
+		if err := encoding.Unmarshal(bs, &StackRewardMap); err != nil {
+			return nil, err
+		}
+	}
+	StackReward, has := StackRewardMap.Get(HyperAddress)
+	if !has {
+		StackReward = amount.NewCoinAmount(0, 0)
+	}
+	var PowerMap *types.AddressAmountMap
+	if sp, has := StakingPowerMap[HyperAddress]; has {
+		if StakingReward, has := re.StakingRewardMap.Get(HyperAddress); has {
+			StackReward = StackReward.Add(StakingReward)
+		}
+		PowerMap = sp.StakingPowerMap
+	} else {
+		PowerMap, err = p.getStakingPowerMap(lw, HyperAddress)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if StackReward.IsZero() {
+		return se, nil
+	}
+
+	Rewards, _, err := p.divideStackReward(lw, frAcc, PowerMap, StackReward)
+	if err != nil {
+		return nil, err
+	}
+	for _, sr := range Rewards {
+		if sr.StakingAddress == StakingAddress {
+			se.RewardAmount = sr.RewardAmount
+			break
+		}
+	}
+	return se, nil
+}
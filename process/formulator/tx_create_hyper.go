@@ -41,7 +41,9 @@ func (tx *CreateHyper) From() common.Address {
 func (tx *CreateHyper) Validate(p types.Process, loader types.LoaderWrapper, signers []common.PublicHash) error {
 	sp := p.(*Formulator)
 
-	if tx.From() != sp.admin.AdminAddress(loader, p.Name()) {
+	if AdminAddress, err := sp.admin.AdminAddress(loader, p.Name()); err != nil {
+		return err
+	} else if tx.From() != AdminAddress {
 		return admin.ErrUnauthorizedTransaction
 	}
 
@@ -0,0 +1,203 @@
+package formulator
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/fletaio/fleta/common"
+	"github.com/fletaio/fleta/common/amount"
+	"github.com/fletaio/fleta/core/types"
+)
+
+// Redelegate moves the staking amount to another hyper formulator without the unstaking delay
+// the moved amount is counted at the destination from the last payout so the reward is neither lost nor doubled
+type Redelegate struct {
+	Timestamp_ uint64
+	Seq_       uint64
+	From_      common.Address
+	FromHyper  common.Address
+	ToHyper    common.Address
+	Amount     *amount.Amount
+}
+
+// Timestamp returns the timestamp of the transaction
+func (tx *Redelegate) Timestamp() uint64 {
+	return tx.Timestamp_
+}
+
+// Seq returns the sequence of the transaction
+func (tx *Redelegate) Seq() uint64 {
+	return tx.Seq_
+}
+
+// From returns the from address of the transaction
+func (tx *Redelegate) From() common.Address {
+	return tx.From_
+}
+
+// Fee returns the fee of the transaction
+func (tx *Redelegate) Fee(p types.Process, loader types.LoaderWrapper) *amount.Amount {
+	sp := p.(*Formulator)
+	return sp.vault.GetDefaultFee(loader)
+}
+
+// Validate validates signatures of the transaction
+func (tx *Redelegate) Validate(p types.Process, loader types.LoaderWrapper, signers []common.PublicHash) error {
+	sp := p.(*Formulator)
+
+	if tx.Amount.Less(amount.COIN.DivC(10)) {
+		return ErrInvalidStakingAmount
+	}
+
+	if tx.Seq() <= loader.Seq(tx.From()) {
+		return types.ErrInvalidSequence
+	}
+
+	if tx.FromHyper == tx.ToHyper {
+		return ErrInvalidStakingAddress
+	}
+	if tx.From() == tx.ToHyper {
+		return ErrInvalidStakingAddress
+	}
+
+	rp, err := sp.GetRewardPolicy(loader)
+	if err != nil {
+		return err
+	}
+	if Height := sp.GetRedelegatedHeight(loader, tx.From()); Height > 0 && loader.TargetHeight() < Height+rp.PayRewardEveryBlocks {
+		return ErrRedelegateCooldown
+	}
+
+	acc, err := loader.Account(tx.FromHyper)
+	if err != nil {
+		return err
+	}
+	srcAcc, is := acc.(*FormulatorAccount)
+	if !is {
+		return types.ErrInvalidAccountType
+	}
+	if srcAcc.FormulatorType != HyperFormulatorType {
+		return types.ErrInvalidAccountType
+	}
+
+	acc, err = loader.Account(tx.ToHyper)
+	if err != nil {
+		return err
+	}
+	dstAcc, is := acc.(*FormulatorAccount)
+	if !is {
+		return types.ErrInvalidAccountType
+	}
+	if dstAcc.FormulatorType != HyperFormulatorType {
+		return types.ErrInvalidAccountType
+	}
+	if dstAcc.IsRevoked {
+		return ErrRevokedFormulator
+	}
+	if !dstAcc.Policy.MinimumStaking.IsZero() && tx.Amount.Less(dstAcc.Policy.MinimumStaking) {
+		return ErrInvalidStakingAmount
+	}
+
+	fromAcc, err := loader.Account(tx.From())
+	if err != nil {
+		return err
+	}
+	if err := fromAcc.Validate(loader, signers); err != nil {
+		return err
+	}
+
+	fromStakingAmount := sp.GetStakingAmount(loader, tx.FromHyper, tx.From())
+	if fromStakingAmount.Less(tx.Amount) {
+		return ErrInsufficientStakingAmount
+	}
+	if srcAcc.StakingAmount.Less(tx.Amount) {
+		return ErrInsufficientStakingAmount
+	}
+
+	if err := sp.vault.CheckFeePayable(p, loader, tx); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Execute updates the context by the transaction
+func (tx *Redelegate) Execute(p types.Process, ctw *types.ContextWrapper, index uint16) error {
+	sp := p.(*Formulator)
+
+	return sp.vault.WithFee(p, ctw, tx, func() error {
+		acc, err := ctw.Account(tx.FromHyper)
+		if err != nil {
+			return err
+		}
+		srcAcc := acc.(*FormulatorAccount)
+
+		acc, err = ctw.Account(tx.ToHyper)
+		if err != nil {
+			return err
+		}
+		dstAcc := acc.(*FormulatorAccount)
+
+		if err := sp.subStakingAmount(ctw, tx.FromHyper, tx.From(), tx.Amount); err != nil {
+			return err
+		}
+		srcAcc.StakingAmount = srcAcc.StakingAmount.Sub(tx.Amount)
+
+		sp.AddStakingAmount(ctw, tx.ToHyper, tx.From(), tx.Amount)
+		dstAcc.StakingAmount = dstAcc.StakingAmount.Add(tx.Amount)
+		if err := sp.addStakingSnapshot(ctw, tx.ToHyper, tx.From(), tx.Amount); err != nil {
+			return err
+		}
+
+		sp.setRedelegatedHeight(ctw, tx.From(), ctw.TargetHeight())
+		return nil
+	})
+}
+
+// MarshalJSON is a marshaler function
+func (tx *Redelegate) MarshalJSON() ([]byte, error) {
+	var buffer bytes.Buffer
+	buffer.WriteString(`{`)
+	buffer.WriteString(`"timestamp":`)
+	if bs, err := json.Marshal(tx.Timestamp_); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"seq":`)
+	if bs, err := json.Marshal(tx.Seq_); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"from":`)
+	if bs, err := tx.From_.MarshalJSON(); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"from_hyper":`)
+	if bs, err := tx.FromHyper.MarshalJSON(); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"to_hyper":`)
+	if bs, err := tx.ToHyper.MarshalJSON(); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"amount":`)
+	if bs, err := tx.Amount.MarshalJSON(); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`}`)
+	return buffer.Bytes(), nil
+}
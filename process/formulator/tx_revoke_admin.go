@@ -44,7 +44,9 @@ func (tx *RevokeAdmin) Fee(p types.Process, loader types.LoaderWrapper) *amount.
 func (tx *RevokeAdmin) Validate(p types.Process, loader types.LoaderWrapper, signers []common.PublicHash) error {
 	sp := p.(*Formulator)
 
-	if tx.From() != sp.admin.AdminAddress(loader, p.Name()) {
+	if AdminAddress, err := sp.admin.AdminAddress(loader, p.Name()); err != nil {
+		return err
+	} else if tx.From() != AdminAddress {
 		return admin.ErrUnauthorizedTransaction
 	}
 	if tx.Formulator == tx.Heritor {
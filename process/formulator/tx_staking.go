@@ -84,6 +84,9 @@ func (tx *Staking) Validate(p types.Process, loader types.LoaderWrapper, signers
 	if err := sp.vault.CheckFeePayableWith(p, loader, tx, tx.Amount); err != nil {
 		return err
 	}
+	if err := sp.vault.CheckSpending(loader, tx.From(), []common.Address{tx.HyperFormulator}, tx.Amount); err != nil {
+		return err
+	}
 	return nil
 }
 
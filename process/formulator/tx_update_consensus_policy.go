@@ -0,0 +1,127 @@
+package formulator
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/fletaio/fleta/common"
+	"github.com/fletaio/fleta/core/types"
+	"github.com/fletaio/fleta/process/admin"
+)
+
+// UpdateConsensusPolicy is used to update consensus policy from the activate height
+type UpdateConsensusPolicy struct {
+	Timestamp_     uint64
+	Seq_           uint64
+	From_          common.Address
+	ActivateHeight uint32
+	Policy         *ConsensusPolicy
+}
+
+// Timestamp returns the timestamp of the transaction
+func (tx *UpdateConsensusPolicy) Timestamp() uint64 {
+	return tx.Timestamp_
+}
+
+// Seq returns the sequence of the transaction
+func (tx *UpdateConsensusPolicy) Seq() uint64 {
+	return tx.Seq_
+}
+
+// From returns the from address of the transaction
+func (tx *UpdateConsensusPolicy) From() common.Address {
+	return tx.From_
+}
+
+// Validate validates signatures of the transaction
+func (tx *UpdateConsensusPolicy) Validate(p types.Process, loader types.LoaderWrapper, signers []common.PublicHash) error {
+	sp := p.(*Formulator)
+
+	if sp.admin.IsGoverned() {
+		return admin.ErrGovernedTransaction
+	}
+
+	if tx.Seq() <= loader.Seq(tx.From()) {
+		return types.ErrInvalidSequence
+	}
+	return tx.ValidatePayload(p, loader, signers)
+}
+
+// ValidatePayload validates the transaction except the sequence
+// the governance process validates proposals by it when proposed and activated
+func (tx *UpdateConsensusPolicy) ValidatePayload(p types.Process, loader types.LoaderWrapper, signers []common.PublicHash) error {
+	sp := p.(*Formulator)
+
+	if has, err := sp.admin.HasRole(loader, p.Name(), admin.RolePolicy, tx.From()); err != nil {
+		return err
+	} else if !has {
+		return admin.ErrUnauthorizedTransaction
+	}
+	if tx.Policy == nil {
+		return ErrInvalidConsensusPolicy
+	}
+	if tx.Policy.MaxBlocksPerFormulator == 0 || tx.Policy.BlockIntervalMS == 0 || tx.Policy.RoundTimeoutMS == 0 {
+		return ErrInvalidConsensusPolicy
+	}
+	if tx.ActivateHeight <= loader.TargetHeight() {
+		return ErrInvalidActivateHeight
+	}
+
+	fromAcc, err := loader.Account(tx.From())
+	if err != nil {
+		return err
+	}
+	if err := fromAcc.Validate(loader, signers); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Execute updates the context by the transaction
+func (tx *UpdateConsensusPolicy) Execute(p types.Process, ctw *types.ContextWrapper, index uint16) error {
+	sp := p.(*Formulator)
+
+	return sp.setPendingConsensusPolicy(ctw, tx.ActivateHeight, tx.Policy)
+}
+
+// MarshalJSON is a marshaler function
+func (tx *UpdateConsensusPolicy) MarshalJSON() ([]byte, error) {
+	var buffer bytes.Buffer
+	buffer.WriteString(`{`)
+	buffer.WriteString(`"timestamp":`)
+	if bs, err := json.Marshal(tx.Timestamp_); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"seq":`)
+	if bs, err := json.Marshal(tx.Seq_); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"from":`)
+	if bs, err := tx.From_.MarshalJSON(); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"activate_height":`)
+	if bs, err := json.Marshal(tx.ActivateHeight); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"policy":`)
+	if bs, err := tx.Policy.MarshalJSON(); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`}`)
+	return buffer.Bytes(), nil
+}
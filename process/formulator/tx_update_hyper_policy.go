@@ -37,16 +37,29 @@ func (tx *UpdateHyperPolicy) From() common.Address {
 func (tx *UpdateHyperPolicy) Validate(p types.Process, loader types.LoaderWrapper, signers []common.PublicHash) error {
 	sp := p.(*Formulator)
 
-	if tx.From() != sp.admin.AdminAddress(loader, p.Name()) {
-		return admin.ErrUnauthorizedTransaction
-	}
-	if tx.Policy == nil {
-		return ErrInvalidHyperPolicy
+	if sp.admin.IsGoverned() {
+		return admin.ErrGovernedTransaction
 	}
 
 	if tx.Seq() <= loader.Seq(tx.From()) {
 		return types.ErrInvalidSequence
 	}
+	return tx.ValidatePayload(p, loader, signers)
+}
+
+// ValidatePayload validates the transaction except the sequence
+// the governance process validates proposals by it when proposed and activated
+func (tx *UpdateHyperPolicy) ValidatePayload(p types.Process, loader types.LoaderWrapper, signers []common.PublicHash) error {
+	sp := p.(*Formulator)
+
+	if has, err := sp.admin.HasRole(loader, p.Name(), admin.RolePolicy, tx.From()); err != nil {
+		return err
+	} else if !has {
+		return admin.ErrUnauthorizedTransaction
+	}
+	if tx.Policy == nil {
+		return ErrInvalidHyperPolicy
+	}
 
 	fromAcc, err := loader.Account(tx.From())
 	if err != nil {
@@ -36,7 +36,9 @@ func (tx *UpdateRewardBaseUpgrade) From() common.Address {
 func (tx *UpdateRewardBaseUpgrade) Validate(p types.Process, loader types.LoaderWrapper, signers []common.PublicHash) error {
 	sp := p.(*Formulator)
 
-	if tx.From() != sp.admin.AdminAddress(loader, p.Name()) {
+	if AdminAddress, err := sp.admin.AdminAddress(loader, p.Name()); err != nil {
+		return err
+	} else if tx.From() != AdminAddress {
 		return admin.ErrUnauthorizedTransaction
 	}
 
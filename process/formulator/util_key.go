@@ -18,6 +18,8 @@ var (
 	tagHyperPolicy              = []byte{2, 4}
 	tagTransmutePolicy          = []byte{2, 5}
 	tagMiningFeePolicy          = []byte{2, 6}
+	tagConsensusPolicy          = []byte{2, 7}
+	tagPendingConsensusPolicy   = []byte{2, 8}
 	tagGenCount                 = []byte{3, 0}
 	tagGenCountNumber           = []byte{3, 1}
 	tagGenCountReverse          = []byte{3, 2}
@@ -37,7 +39,12 @@ var (
 	tagUnstakingAmountNumber    = []byte{6, 1}
 	tagUnstakingAmountReverse   = []byte{6, 2}
 	tagUnstakingAmountCount     = []byte{6, 3}
+	tagUnstakingHeight          = []byte{6, 4}
+	tagUnstakingHeightReverse   = []byte{6, 5}
+	tagUnstakingHeightCount     = []byte{6, 6}
+	tagUnstakingHeightBackfill  = []byte{6, 7}
 	tagRewardBaseUpgrade        = []byte{7, 0}
+	tagRedelegatedHeight        = []byte{8, 0}
 )
 
 func toStakingAmountKey(StakingAddrss common.Address) []byte {
@@ -150,3 +157,17 @@ func toUnstakingAmountCountKey(height uint32) []byte {
 	binutil.BigEndian.PutUint32(bs[2:], height)
 	return bs
 }
+
+func toUnstakingHeightKey(height uint32) []byte {
+	bs := make([]byte, 6)
+	copy(bs, tagUnstakingHeight)
+	binutil.BigEndian.PutUint32(bs[2:], height)
+	return bs
+}
+
+func toUnstakingHeightReverseKey(num uint32) []byte {
+	bs := make([]byte, 6)
+	copy(bs, tagUnstakingHeightReverse)
+	binutil.BigEndian.PutUint32(bs[2:], num)
+	return bs
+}
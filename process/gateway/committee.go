@@ -0,0 +1,120 @@
+package gateway
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/fletaio/fleta/common"
+	"github.com/fletaio/fleta/core/types"
+	"github.com/fletaio/fleta/encoding"
+	"github.com/fletaio/fleta/process/admin"
+)
+
+// Committee is the signer committee of the platform
+// TokenIn and TokenLeave of the platform need Required signatures of the committee
+type Committee struct {
+	Required  uint8
+	KeyHashes []common.PublicHash
+}
+
+// MarshalJSON is a marshaler function
+func (cm *Committee) MarshalJSON() ([]byte, error) {
+	var buffer bytes.Buffer
+	buffer.WriteString(`{`)
+	buffer.WriteString(`"required":`)
+	if bs, err := json.Marshal(cm.Required); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"key_hashes":`)
+	buffer.WriteString(`[`)
+	for i, v := range cm.KeyHashes {
+		if i > 0 {
+			buffer.WriteString(`,`)
+		}
+		if bs, err := v.MarshalJSON(); err != nil {
+			return nil, err
+		} else {
+			buffer.Write(bs)
+		}
+	}
+	buffer.WriteString(`]`)
+	buffer.WriteString(`}`)
+	return buffer.Bytes(), nil
+}
+
+// Validate checks that signers contain the required number of committee members
+func (cm *Committee) Validate(signers []common.PublicHash) error {
+	signerMap := map[common.PublicHash]bool{}
+	for _, signer := range signers {
+		signerMap[signer] = true
+	}
+	matchCount := 0
+	for _, pubhash := range cm.KeyHashes {
+		if signerMap[pubhash] {
+			matchCount++
+		}
+	}
+	if matchCount < int(cm.Required) {
+		return ErrInsufficientCommitteeSigner
+	}
+	return nil
+}
+
+// Committee returns the signer committee of the platform
+func (p *Gateway) Committee(loader types.Loader, Platform string) (*Committee, error) {
+	lw := types.NewLoaderWrapper(p.pid, loader)
+
+	if bs := lw.ProcessData(toPlatformKey(Platform)); len(bs) == 0 {
+		return nil, ErrNotSupportedPlatform
+	}
+	bs := lw.ProcessData(toCommitteeKey(Platform))
+	if len(bs) == 0 {
+		return nil, ErrNotExistCommittee
+	}
+	cm := &Committee{}
+	if err := encoding.Unmarshal(bs, &cm); err != nil {
+		return nil, err
+	}
+	return cm, nil
+}
+
+// SetCommittee sets the signer committee of the platform
+// nil committee removes it and settlements of the platform are stopped until the admin sets a new committee
+func (p *Gateway) SetCommittee(ctw *types.ContextWrapper, Platform string, cm *Committee) error {
+	ctw = types.SwitchContextWrapper(p.pid, ctw)
+
+	if bs := ctw.ProcessData(toPlatformKey(Platform)); len(bs) == 0 {
+		return ErrNotSupportedPlatform
+	}
+	if cm == nil {
+		ctw.SetProcessData(toCommitteeKey(Platform), nil)
+		return nil
+	}
+	bs, err := encoding.Marshal(cm)
+	if err != nil {
+		return err
+	}
+	ctw.SetProcessData(toCommitteeKey(Platform), bs)
+	return nil
+}
+
+// validateSigners checks signers of the settlement transaction of the platform
+// the from address should hold the role and signers should meet the threshold of the committee of the platform
+func (p *Gateway) validateSigners(loader types.LoaderWrapper, Platform string, Role string, From common.Address, signers []common.PublicHash) error {
+	if has, err := p.admin.HasRole(loader, p.Name(), Role, From); err != nil {
+		return err
+	} else if !has {
+		return admin.ErrUnauthorizedTransaction
+	}
+	cm, err := p.Committee(loader, Platform)
+	if err != nil {
+		return err
+	}
+	if err := cm.Validate(signers); err != nil {
+		return err
+	}
+	return nil
+}
@@ -16,4 +16,13 @@ var (
 	ErrPolicyShouldBeSetupInApplication = errors.New("policy should be setup in application")
 	ErrNotSupportedPlatform             = errors.New("not supported platform")
 	ErrAlreadySupportedPlatform         = errors.New("already supported platform")
+	ErrNotExistCommittee                = errors.New("not exist committee")
+	ErrInsufficientCommitteeSigner      = errors.New("insufficient committee signer")
+	ErrNotExistWithdrawal               = errors.New("not exist withdrawal")
+	ErrInvalidWithdrawalStatus          = errors.New("invalid withdrawal status")
+	ErrUnderMinAmount                   = errors.New("under min amount")
+	ErrExceedMaxAmount                  = errors.New("exceed max amount")
+	ErrExceedDailyLimit                 = errors.New("exceed daily limit")
+	ErrPlatformPaused                   = errors.New("platform paused")
+	ErrMismatchedWithdrawal             = errors.New("mismatched withdrawal")
 )
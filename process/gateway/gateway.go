@@ -4,6 +4,7 @@ import (
 	"github.com/fletaio/fleta/core/types"
 	"github.com/fletaio/fleta/process/admin"
 	"github.com/fletaio/fleta/process/vault"
+	"github.com/fletaio/fleta/service/apiserver"
 )
 
 // Gateway manages balance of accounts of the chain
@@ -64,6 +65,46 @@ func (p *Gateway) Init(reg *types.Register, pm types.ProcessManager, cn types.Pr
 	reg.RegisterTransaction(3, &TokenLeave{})
 	reg.RegisterTransaction(4, &UpdatePolicy{})
 	reg.RegisterTransaction(5, &AddPlatform{})
+	reg.RegisterTransaction(6, &UpdateCommittee{})
+	reg.RegisterTransaction(7, &ProcessWithdrawal{})
+	reg.RegisterTransaction(8, &RefundWithdrawal{})
+	reg.RegisterTransaction(9, &SetPlatformPaused{})
+
+	if vs, err := pm.ServiceByName("fleta.apiserver"); err != nil {
+		//ignore when not loaded
+	} else if v, is := vs.(*apiserver.APIServer); !is {
+		//ignore when not loaded
+	} else {
+		s, err := v.JRPC("gateway")
+		if err != nil {
+			return err
+		}
+		s.Set("withdrawal", func(ID interface{}, arg *apiserver.Argument) (interface{}, error) {
+			if arg.Len() != 1 {
+				return nil, apiserver.ErrInvalidArgument
+			}
+			CoinTXID, err := arg.String(0)
+			if err != nil {
+				return nil, err
+			}
+			loader := cn.NewLoaderWrapper(p.ID())
+			return p.Withdrawal(loader, CoinTXID)
+		})
+		s.Set("pendingWithdrawals", func(ID interface{}, arg *apiserver.Argument) (interface{}, error) {
+			if arg.Len() != 1 {
+				return nil, apiserver.ErrInvalidArgument
+			}
+			Platform, err := arg.String(0)
+			if err != nil {
+				return nil, err
+			}
+			loader := cn.NewLoaderWrapper(p.ID())
+			if _, err := p.GetPolicy(loader, Platform); err != nil {
+				return nil, err
+			}
+			return p.PendingWithdrawals(loader, Platform)
+		})
+	}
 	return nil
 }
 
@@ -78,7 +119,9 @@ func (p *Gateway) InitPolicy(ctw *types.ContextWrapper, Platform string, policy
 func (p *Gateway) OnLoadChain(loader types.LoaderWrapper) error {
 	lw := types.NewLoaderWrapper(p.pid, loader)
 
-	p.admin.AdminAddress(loader, p.Name())
+	if _, err := p.admin.AdminAddress(loader, p.Name()); err != nil {
+		return err
+	}
 	Platforms := p.Platforms(loader)
 	for _, v := range Platforms {
 		if bs := lw.ProcessData(toPolicyKey(v)); len(bs) == 0 {
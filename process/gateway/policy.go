@@ -7,9 +7,71 @@ import (
 	"github.com/fletaio/fleta/common/amount"
 )
 
+// BlocksPerDay is the number of blocks of the window of daily volume caps
+const BlocksPerDay = 172800
+
 // Policy defines a policy of gateway
+// nil limits are not checked and fields except WithdrawFee are omitted from the encoding when empty to keep stored policies
 type Policy struct {
-	WithdrawFee *amount.Amount
+	WithdrawFee        *amount.Amount
+	MinTokenIn         *amount.Amount `msgpack:",omitempty"`
+	MaxTokenIn         *amount.Amount `msgpack:",omitempty"`
+	MinTokenOut        *amount.Amount `msgpack:",omitempty"`
+	MaxTokenOut        *amount.Amount `msgpack:",omitempty"`
+	DailyTokenInLimit  *amount.Amount `msgpack:",omitempty"`
+	DailyTokenOutLimit *amount.Amount `msgpack:",omitempty"`
+	IsPaused           bool           `msgpack:",omitempty"`
+}
+
+// Validate checks that limits of the policy are consistent
+func (pc *Policy) Validate() error {
+	zero := amount.NewCoinAmount(0, 0)
+	if pc.WithdrawFee == nil || pc.WithdrawFee.Less(zero) {
+		return ErrInvalidPolicy
+	}
+	for _, am := range []*amount.Amount{pc.MinTokenIn, pc.MaxTokenIn, pc.MinTokenOut, pc.MaxTokenOut, pc.DailyTokenInLimit, pc.DailyTokenOutLimit} {
+		if am != nil && am.Less(zero) {
+			return ErrInvalidPolicy
+		}
+	}
+	if pc.MinTokenIn != nil && pc.MaxTokenIn != nil && pc.MaxTokenIn.Less(pc.MinTokenIn) {
+		return ErrInvalidPolicy
+	}
+	if pc.MinTokenOut != nil && pc.MaxTokenOut != nil && pc.MaxTokenOut.Less(pc.MinTokenOut) {
+		return ErrInvalidPolicy
+	}
+	return nil
+}
+
+// CheckTokenIn checks the amount of the TokenIn transaction with limits of the policy
+func (pc *Policy) CheckTokenIn(am *amount.Amount, DailyVolume *amount.Amount) error {
+	if pc.MinTokenIn != nil && am.Less(pc.MinTokenIn) {
+		return ErrUnderMinAmount
+	}
+	if pc.MaxTokenIn != nil && pc.MaxTokenIn.Less(am) {
+		return ErrExceedMaxAmount
+	}
+	if pc.DailyTokenInLimit != nil && pc.DailyTokenInLimit.Less(DailyVolume.Add(am)) {
+		return ErrExceedDailyLimit
+	}
+	return nil
+}
+
+// CheckTokenOut checks the amount of the TokenOut transaction with limits of the policy
+func (pc *Policy) CheckTokenOut(am *amount.Amount, DailyVolume *amount.Amount) error {
+	if pc.IsPaused {
+		return ErrPlatformPaused
+	}
+	if pc.MinTokenOut != nil && am.Less(pc.MinTokenOut) {
+		return ErrUnderMinAmount
+	}
+	if pc.MaxTokenOut != nil && pc.MaxTokenOut.Less(am) {
+		return ErrExceedMaxAmount
+	}
+	if pc.DailyTokenOutLimit != nil && pc.DailyTokenOutLimit.Less(DailyVolume.Add(am)) {
+		return ErrExceedDailyLimit
+	}
+	return nil
 }
 
 // MarshalJSON is a marshaler function
@@ -22,6 +84,55 @@ func (pc *Policy) MarshalJSON() ([]byte, error) {
 	} else {
 		buffer.Write(bs)
 	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"min_token_in":`)
+	if bs, err := json.Marshal(pc.MinTokenIn); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"max_token_in":`)
+	if bs, err := json.Marshal(pc.MaxTokenIn); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"min_token_out":`)
+	if bs, err := json.Marshal(pc.MinTokenOut); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"max_token_out":`)
+	if bs, err := json.Marshal(pc.MaxTokenOut); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"daily_token_in_limit":`)
+	if bs, err := json.Marshal(pc.DailyTokenInLimit); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"daily_token_out_limit":`)
+	if bs, err := json.Marshal(pc.DailyTokenOutLimit); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"is_paused":`)
+	if bs, err := json.Marshal(pc.IsPaused); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
 	buffer.WriteString(`}`)
 	return buffer.Bytes(), nil
 }
@@ -37,12 +37,17 @@ func (tx *AddPlatform) From() common.Address {
 func (tx *AddPlatform) Validate(p types.Process, loader types.LoaderWrapper, signers []common.PublicHash) error {
 	sp := p.(*Gateway)
 
-	if tx.From() != sp.admin.AdminAddress(loader, p.Name()) {
+	if AdminAddress, err := sp.admin.AdminAddress(loader, p.Name()); err != nil {
+		return err
+	} else if tx.From() != AdminAddress {
 		return admin.ErrUnauthorizedTransaction
 	}
 	if tx.Policy == nil {
 		return ErrInvalidPolicy
 	}
+	if err := tx.Policy.Validate(); err != nil {
+		return err
+	}
 
 	if tx.Seq() <= loader.Seq(tx.From()) {
 		return types.ErrInvalidSequence
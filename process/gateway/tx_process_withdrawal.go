@@ -0,0 +1,121 @@
+package gateway
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/fletaio/fleta/common"
+	"github.com/fletaio/fleta/core/types"
+	"github.com/fletaio/fleta/process/admin"
+)
+
+// ProcessWithdrawal marks the requested withdrawal as processing when the payout is started
+type ProcessWithdrawal struct {
+	Timestamp_ uint64
+	Seq_       uint64
+	From_      common.Address
+	Platform   string
+	CoinTXID   string
+}
+
+// Timestamp returns the timestamp of the transaction
+func (tx *ProcessWithdrawal) Timestamp() uint64 {
+	return tx.Timestamp_
+}
+
+// Seq returns the sequence of the transaction
+func (tx *ProcessWithdrawal) Seq() uint64 {
+	return tx.Seq_
+}
+
+// From returns the from address of the transaction
+func (tx *ProcessWithdrawal) From() common.Address {
+	return tx.From_
+}
+
+// Validate validates signatures of the transaction
+func (tx *ProcessWithdrawal) Validate(p types.Process, loader types.LoaderWrapper, signers []common.PublicHash) error {
+	sp := p.(*Gateway)
+
+	if tx.Seq() <= loader.Seq(tx.From()) {
+		return types.ErrInvalidSequence
+	}
+
+	if policy, err := sp.GetPolicy(loader, tx.Platform); err != nil {
+		return err
+	} else if policy.IsPaused {
+		return ErrPlatformPaused
+	}
+	wd, err := sp.Withdrawal(loader, tx.CoinTXID)
+	if err != nil {
+		return err
+	}
+	if wd.Platform != tx.Platform {
+		return ErrNotExistWithdrawal
+	}
+	if wd.Status != WithdrawalRequested {
+		return ErrInvalidWithdrawalStatus
+	}
+
+	if err := sp.validateSigners(loader, tx.Platform, admin.RoleAdmin, tx.From(), signers); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Execute updates the context by the transaction
+func (tx *ProcessWithdrawal) Execute(p types.Process, ctw *types.ContextWrapper, index uint16) error {
+	sp := p.(*Gateway)
+
+	wd, err := sp.Withdrawal(ctw, tx.CoinTXID)
+	if err != nil {
+		return err
+	}
+	if wd.Status != WithdrawalRequested {
+		return ErrInvalidWithdrawalStatus
+	}
+	wd.Status = WithdrawalProcessing
+	return sp.setWithdrawal(ctw, wd)
+}
+
+// MarshalJSON is a marshaler function
+func (tx *ProcessWithdrawal) MarshalJSON() ([]byte, error) {
+	var buffer bytes.Buffer
+	buffer.WriteString(`{`)
+	buffer.WriteString(`"timestamp":`)
+	if bs, err := json.Marshal(tx.Timestamp_); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"seq":`)
+	if bs, err := json.Marshal(tx.Seq_); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"from":`)
+	if bs, err := tx.From_.MarshalJSON(); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"platform":`)
+	if bs, err := json.Marshal(tx.Platform); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"coin_txid":`)
+	if bs, err := json.Marshal(tx.CoinTXID); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`}`)
+	return buffer.Bytes(), nil
+}
@@ -0,0 +1,133 @@
+package gateway
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/fletaio/fleta/common"
+	"github.com/fletaio/fleta/core/types"
+	"github.com/fletaio/fleta/process/admin"
+	"github.com/fletaio/fleta/process/vault"
+)
+
+// RefundWithdrawal returns the amount of the failed payout to the requester
+// the withdraw fee is not returned
+type RefundWithdrawal struct {
+	Timestamp_ uint64
+	Seq_       uint64
+	From_      common.Address
+	Platform   string
+	CoinTXID   string
+}
+
+// Timestamp returns the timestamp of the transaction
+func (tx *RefundWithdrawal) Timestamp() uint64 {
+	return tx.Timestamp_
+}
+
+// Seq returns the sequence of the transaction
+func (tx *RefundWithdrawal) Seq() uint64 {
+	return tx.Seq_
+}
+
+// From returns the from address of the transaction
+func (tx *RefundWithdrawal) From() common.Address {
+	return tx.From_
+}
+
+// Validate validates signatures of the transaction
+func (tx *RefundWithdrawal) Validate(p types.Process, loader types.LoaderWrapper, signers []common.PublicHash) error {
+	sp := p.(*Gateway)
+
+	if tx.Seq() <= loader.Seq(tx.From()) {
+		return types.ErrInvalidSequence
+	}
+
+	wd, err := sp.Withdrawal(loader, tx.CoinTXID)
+	if err != nil {
+		return err
+	}
+	if wd.Platform != tx.Platform {
+		return ErrNotExistWithdrawal
+	}
+	if !wd.IsPending() {
+		return ErrInvalidWithdrawalStatus
+	}
+	if sp.vault.Balance(loader, tx.From()).Less(wd.Amount) {
+		return vault.ErrMinusBalance
+	}
+	if err := sp.vault.CheckSpending(loader, tx.From(), []common.Address{wd.CoinFrom}, wd.Amount); err != nil {
+		return err
+	}
+
+	if err := sp.validateSigners(loader, tx.Platform, admin.RoleAdmin, tx.From(), signers); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Execute updates the context by the transaction
+func (tx *RefundWithdrawal) Execute(p types.Process, ctw *types.ContextWrapper, index uint16) error {
+	sp := p.(*Gateway)
+
+	wd, err := sp.Withdrawal(ctw, tx.CoinTXID)
+	if err != nil {
+		return err
+	}
+	if !wd.IsPending() {
+		return ErrInvalidWithdrawalStatus
+	}
+	if err := sp.vault.SubBalance(ctw, tx.From(), wd.Amount); err != nil {
+		return err
+	}
+	if err := sp.vault.AddBalance(ctw, wd.CoinFrom, wd.Amount); err != nil {
+		return err
+	}
+	wd.Status = WithdrawalRefunded
+	if err := sp.setWithdrawal(ctw, wd); err != nil {
+		return err
+	}
+	return sp.SetOutTXIDProcessed(ctw, tx.Platform, tx.CoinTXID)
+}
+
+// MarshalJSON is a marshaler function
+func (tx *RefundWithdrawal) MarshalJSON() ([]byte, error) {
+	var buffer bytes.Buffer
+	buffer.WriteString(`{`)
+	buffer.WriteString(`"timestamp":`)
+	if bs, err := json.Marshal(tx.Timestamp_); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"seq":`)
+	if bs, err := json.Marshal(tx.Seq_); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"from":`)
+	if bs, err := tx.From_.MarshalJSON(); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"platform":`)
+	if bs, err := json.Marshal(tx.Platform); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"coin_txid":`)
+	if bs, err := json.Marshal(tx.CoinTXID); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`}`)
+	return buffer.Bytes(), nil
+}
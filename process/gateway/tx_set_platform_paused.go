@@ -0,0 +1,125 @@
+package gateway
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/fletaio/fleta/common"
+	"github.com/fletaio/fleta/core/types"
+	"github.com/fletaio/fleta/process/admin"
+)
+
+// SetPlatformPaused pauses or resumes outbound flows of the platform
+// it is accepted by the admin key or the threshold of the committee
+type SetPlatformPaused struct {
+	Timestamp_ uint64
+	Seq_       uint64
+	From_      common.Address
+	Platform   string
+	IsPaused   bool
+}
+
+// Timestamp returns the timestamp of the transaction
+func (tx *SetPlatformPaused) Timestamp() uint64 {
+	return tx.Timestamp_
+}
+
+// Seq returns the sequence of the transaction
+func (tx *SetPlatformPaused) Seq() uint64 {
+	return tx.Seq_
+}
+
+// From returns the from address of the transaction
+func (tx *SetPlatformPaused) From() common.Address {
+	return tx.From_
+}
+
+// Validate validates signatures of the transaction
+func (tx *SetPlatformPaused) Validate(p types.Process, loader types.LoaderWrapper, signers []common.PublicHash) error {
+	sp := p.(*Gateway)
+
+	if AdminAddress, err := sp.admin.AdminAddress(loader, p.Name()); err != nil {
+		return err
+	} else if tx.From() != AdminAddress {
+		return admin.ErrUnauthorizedTransaction
+	}
+	if _, err := sp.GetPolicy(loader, tx.Platform); err != nil {
+		return err
+	}
+
+	if tx.Seq() <= loader.Seq(tx.From()) {
+		return types.ErrInvalidSequence
+	}
+
+	fromAcc, err := loader.Account(tx.From())
+	if err != nil {
+		return err
+	}
+	isAdminSigned := fromAcc.Validate(loader, signers) == nil
+	isCommitteeSigned := false
+	if cm, err := sp.Committee(loader, tx.Platform); err != nil {
+		if err != ErrNotExistCommittee {
+			return err
+		}
+	} else {
+		isCommitteeSigned = cm.Validate(signers) == nil
+	}
+	if !isAdminSigned && !isCommitteeSigned {
+		return admin.ErrUnauthorizedTransaction
+	}
+	return nil
+}
+
+// Execute updates the context by the transaction
+func (tx *SetPlatformPaused) Execute(p types.Process, ctw *types.ContextWrapper, index uint16) error {
+	sp := p.(*Gateway)
+
+	policy, err := sp.GetPolicy(ctw, tx.Platform)
+	if err != nil {
+		return err
+	}
+	policy.IsPaused = tx.IsPaused
+	return sp.SetPolicy(ctw, tx.Platform, policy)
+}
+
+// MarshalJSON is a marshaler function
+func (tx *SetPlatformPaused) MarshalJSON() ([]byte, error) {
+	var buffer bytes.Buffer
+	buffer.WriteString(`{`)
+	buffer.WriteString(`"timestamp":`)
+	if bs, err := json.Marshal(tx.Timestamp_); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"seq":`)
+	if bs, err := json.Marshal(tx.Seq_); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"from":`)
+	if bs, err := tx.From_.MarshalJSON(); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"platform":`)
+	if bs, err := json.Marshal(tx.Platform); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"is_paused":`)
+	if bs, err := json.Marshal(tx.IsPaused); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`}`)
+	return buffer.Bytes(), nil
+}
@@ -42,9 +42,6 @@ func (tx *TokenIn) From() common.Address {
 func (tx *TokenIn) Validate(p types.Process, loader types.LoaderWrapper, signers []common.PublicHash) error {
 	sp := p.(*Gateway)
 
-	if tx.From() != sp.admin.AdminAddress(loader, p.Name()) {
-		return admin.ErrUnauthorizedTransaction
-	}
 	if len(tx.Amounts) != len(tx.ToAddresses) {
 		return ErrInvalidAddressCount
 	}
@@ -57,6 +54,14 @@ func (tx *TokenIn) Validate(p types.Process, loader types.LoaderWrapper, signers
 		return types.ErrInvalidSequence
 	}
 
+	policy, err := sp.GetPolicy(loader, tx.Platform)
+	if err != nil {
+		return err
+	}
+	if err := policy.CheckTokenIn(tx.TotalAmount(), sp.DailyTokenInVolume(loader, tx.Platform)); err != nil {
+		return err
+	}
+
 	if is, err := sp.IsProcessedERC20TXID(loader, tx.Platform, tx.ERC20TXID); err != nil {
 		return err
 	} else if is {
@@ -71,11 +76,10 @@ func (tx *TokenIn) Validate(p types.Process, loader types.LoaderWrapper, signers
 		}
 	}
 
-	fromAcc, err := loader.Account(tx.From())
-	if err != nil {
+	if err := sp.validateSigners(loader, tx.Platform, admin.RoleTokenIn, tx.From(), signers); err != nil {
 		return err
 	}
-	if err := fromAcc.Validate(loader, signers); err != nil {
+	if err := sp.vault.CheckSpending(loader, tx.From(), tx.ToAddresses, tx.TotalAmount()); err != nil {
 		return err
 	}
 	return nil
@@ -96,9 +100,25 @@ func (tx *TokenIn) Execute(p types.Process, ctw *types.ContextWrapper, index uin
 	if err := sp.SetERC20TXIDProcessed(ctw, tx.Platform, tx.ERC20TXID); err != nil {
 		return err
 	}
+	policy, err := sp.GetPolicy(ctw, tx.Platform)
+	if err != nil {
+		return err
+	}
+	if err := sp.addDailyVolume(ctw, tagTokenInVolume, tx.Platform, policy.DailyTokenInLimit, tx.TotalAmount()); err != nil {
+		return err
+	}
 	return nil
 }
 
+// TotalAmount returns the sum of amounts of the transaction
+func (tx *TokenIn) TotalAmount() *amount.Amount {
+	sum := amount.NewCoinAmount(0, 0)
+	for _, am := range tx.Amounts {
+		sum = sum.Add(am)
+	}
+	return sum
+}
+
 // MarshalJSON is a marshaler function
 func (tx *TokenIn) MarshalJSON() ([]byte, error) {
 	var buffer bytes.Buffer
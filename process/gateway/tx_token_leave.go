@@ -43,9 +43,6 @@ func (tx *TokenLeave) From() common.Address {
 func (tx *TokenLeave) Validate(p types.Process, loader types.LoaderWrapper, signers []common.PublicHash) error {
 	sp := p.(*Gateway)
 
-	if tx.From() != sp.admin.AdminAddress(loader, p.Name()) {
-		return admin.ErrUnauthorizedTransaction
-	}
 	if tx.Amount.Less(amount.COIN.DivC(10)) {
 		return types.ErrDustAmount
 	}
@@ -56,17 +53,30 @@ func (tx *TokenLeave) Validate(p types.Process, loader types.LoaderWrapper, sign
 		return types.ErrInvalidSequence
 	}
 
+	if policy, err := sp.GetPolicy(loader, tx.Platform); err != nil {
+		return err
+	} else if policy.IsPaused {
+		return ErrPlatformPaused
+	}
 	if is, err := sp.IsProcessedOutTXID(loader, tx.Platform, tx.CoinTXID); err != nil {
 		return err
 	} else if is {
 		return ErrProcessedOutTXID
 	}
-
-	fromAcc, err := loader.Account(tx.From())
-	if err != nil {
-		return err
+	if wd, err := sp.Withdrawal(loader, tx.CoinTXID); err != nil {
+		if err != ErrNotExistWithdrawal {
+			return err
+		}
+	} else {
+		if !wd.IsPending() {
+			return ErrInvalidWithdrawalStatus
+		}
+		if wd.Platform != tx.Platform || wd.CoinFrom != tx.CoinFrom || wd.ERC20To != tx.ERC20To || !wd.Amount.Equal(tx.Amount) {
+			return ErrMismatchedWithdrawal
+		}
 	}
-	if err := fromAcc.Validate(loader, signers); err != nil {
+
+	if err := sp.validateSigners(loader, tx.Platform, admin.RoleAdmin, tx.From(), signers); err != nil {
 		return err
 	}
 	return nil
@@ -78,6 +88,17 @@ func (tx *TokenLeave) Execute(p types.Process, ctw *types.ContextWrapper, index
 	if err := sp.SetOutTXIDProcessed(ctw, tx.Platform, tx.CoinTXID); err != nil {
 		return err
 	}
+	if wd, err := sp.Withdrawal(ctw, tx.CoinTXID); err != nil {
+		if err != ErrNotExistWithdrawal {
+			return err
+		}
+	} else {
+		wd.Status = WithdrawalCompleted
+		wd.ERC20TXID = tx.ERC20TXID
+		if err := sp.setWithdrawal(ctw, wd); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
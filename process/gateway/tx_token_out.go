@@ -51,7 +51,10 @@ func (tx *TokenOut) Validate(p types.Process, loader types.LoaderWrapper, signer
 		return types.ErrInvalidSequence
 	}
 
-	AdminAddress := sp.admin.AdminAddress(loader, p.Name())
+	AdminAddress, err := sp.admin.AdminAddress(loader, p.Name())
+	if err != nil {
+		return err
+	}
 	if has, err := loader.HasAccount(AdminAddress); err != nil {
 		return err
 	} else if !has {
@@ -70,9 +73,16 @@ func (tx *TokenOut) Validate(p types.Process, loader types.LoaderWrapper, signer
 	if err != nil {
 		return err
 	}
+	if err := policy.CheckTokenOut(tx.Amount, sp.DailyTokenOutVolume(loader, tx.Platform)); err != nil {
+		return err
+	}
 	if err := sp.vault.CheckFeePayableWith(p, loader, tx, tx.Amount.Add(policy.WithdrawFee)); err != nil {
 		return err
 	}
+	// coins are moved to the gateway admin so it should be allowed by the spending policy of the sender
+	if err := sp.vault.CheckSpending(loader, tx.From(), []common.Address{AdminAddress}, tx.Amount.Add(policy.WithdrawFee)); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -91,13 +101,29 @@ func (tx *TokenOut) Execute(p types.Process, ctw *types.ContextWrapper, index ui
 		if err := sp.vault.SubBalance(ctw, tx.From(), tx.Amount); err != nil {
 			return err
 		}
-		AdminAddress := sp.admin.AdminAddress(ctw, p.Name())
+		AdminAddress, err := sp.admin.AdminAddress(ctw, p.Name())
+		if err != nil {
+			return err
+		}
 		if err := sp.vault.AddBalance(ctw, AdminAddress, policy.WithdrawFee); err != nil {
 			return err
 		}
 		if err := sp.vault.AddBalance(ctw, AdminAddress, tx.Amount); err != nil {
 			return err
 		}
+		if err := sp.addDailyVolume(ctw, tagTokenOutVolume, tx.Platform, policy.DailyTokenOutLimit, tx.Amount); err != nil {
+			return err
+		}
+		if err := sp.setWithdrawal(ctw, &Withdrawal{
+			Platform: tx.Platform,
+			CoinTXID: types.TransactionID(ctw.TargetHeight(), index),
+			CoinFrom: tx.From(),
+			ERC20To:  tx.ERC20To,
+			Amount:   tx.Amount,
+			Status:   WithdrawalRequested,
+		}); err != nil {
+			return err
+		}
 		return nil
 	})
 }
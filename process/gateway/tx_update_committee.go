@@ -0,0 +1,146 @@
+package gateway
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/fletaio/fleta/common"
+	"github.com/fletaio/fleta/core/types"
+	"github.com/fletaio/fleta/process/admin"
+	"github.com/fletaio/fleta/process/vault"
+)
+
+// UpdateCommittee is used to update the signer committee of the platform
+// empty KeyHashes removes the committee
+// the admin key sets the first committee and the threshold of the current committee changes or removes it
+type UpdateCommittee struct {
+	Timestamp_ uint64
+	Seq_       uint64
+	From_      common.Address
+	Platform   string
+	Required   uint8
+	KeyHashes  []common.PublicHash
+}
+
+// Timestamp returns the timestamp of the transaction
+func (tx *UpdateCommittee) Timestamp() uint64 {
+	return tx.Timestamp_
+}
+
+// Seq returns the sequence of the transaction
+func (tx *UpdateCommittee) Seq() uint64 {
+	return tx.Seq_
+}
+
+// From returns the from address of the transaction
+func (tx *UpdateCommittee) From() common.Address {
+	return tx.From_
+}
+
+// Validate validates signatures of the transaction
+func (tx *UpdateCommittee) Validate(p types.Process, loader types.LoaderWrapper, signers []common.PublicHash) error {
+	sp := p.(*Gateway)
+
+	if AdminAddress, err := sp.admin.AdminAddress(loader, p.Name()); err != nil {
+		return err
+	} else if tx.From() != AdminAddress {
+		return admin.ErrUnauthorizedTransaction
+	}
+	if len(tx.KeyHashes) > 0 {
+		if err := vault.ValidateKeyHashes(tx.Required, tx.KeyHashes, 20); err != nil {
+			return err
+		}
+	}
+	if _, err := sp.GetPolicy(loader, tx.Platform); err != nil {
+		return err
+	}
+
+	if tx.Seq() <= loader.Seq(tx.From()) {
+		return types.ErrInvalidSequence
+	}
+
+	if cm, err := sp.Committee(loader, tx.Platform); err != nil {
+		if err != ErrNotExistCommittee {
+			return err
+		}
+		fromAcc, err := loader.Account(tx.From())
+		if err != nil {
+			return err
+		}
+		if err := fromAcc.Validate(loader, signers); err != nil {
+			return err
+		}
+	} else if err := cm.Validate(signers); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Execute updates the context by the transaction
+func (tx *UpdateCommittee) Execute(p types.Process, ctw *types.ContextWrapper, index uint16) error {
+	sp := p.(*Gateway)
+
+	if len(tx.KeyHashes) == 0 {
+		return sp.SetCommittee(ctw, tx.Platform, nil)
+	}
+	return sp.SetCommittee(ctw, tx.Platform, &Committee{
+		Required:  tx.Required,
+		KeyHashes: tx.KeyHashes,
+	})
+}
+
+// MarshalJSON is a marshaler function
+func (tx *UpdateCommittee) MarshalJSON() ([]byte, error) {
+	var buffer bytes.Buffer
+	buffer.WriteString(`{`)
+	buffer.WriteString(`"timestamp":`)
+	if bs, err := json.Marshal(tx.Timestamp_); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"seq":`)
+	if bs, err := json.Marshal(tx.Seq_); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"from":`)
+	if bs, err := tx.From_.MarshalJSON(); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"platform":`)
+	if bs, err := json.Marshal(tx.Platform); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"required":`)
+	if bs, err := json.Marshal(tx.Required); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"key_hashes":`)
+	buffer.WriteString(`[`)
+	for i, v := range tx.KeyHashes {
+		if i > 0 {
+			buffer.WriteString(`,`)
+		}
+		if bs, err := v.MarshalJSON(); err != nil {
+			return nil, err
+		} else {
+			buffer.Write(bs)
+		}
+	}
+	buffer.WriteString(`]`)
+	buffer.WriteString(`}`)
+	return buffer.Bytes(), nil
+}
@@ -37,15 +37,31 @@ func (tx *UpdatePolicy) From() common.Address {
 func (tx *UpdatePolicy) Validate(p types.Process, loader types.LoaderWrapper, signers []common.PublicHash) error {
 	sp := p.(*Gateway)
 
-	if tx.From() != sp.admin.AdminAddress(loader, p.Name()) {
+	if sp.admin.IsGoverned() {
+		return admin.ErrGovernedTransaction
+	}
+
+	if tx.Seq() <= loader.Seq(tx.From()) {
+		return types.ErrInvalidSequence
+	}
+	return tx.ValidatePayload(p, loader, signers)
+}
+
+// ValidatePayload validates the transaction except the sequence
+// the governance process validates proposals by it when proposed and activated
+func (tx *UpdatePolicy) ValidatePayload(p types.Process, loader types.LoaderWrapper, signers []common.PublicHash) error {
+	sp := p.(*Gateway)
+
+	if has, err := sp.admin.HasRole(loader, p.Name(), admin.RolePolicy, tx.From()); err != nil {
+		return err
+	} else if !has {
 		return admin.ErrUnauthorizedTransaction
 	}
 	if tx.Policy == nil {
 		return ErrInvalidPolicy
 	}
-
-	if tx.Seq() <= loader.Seq(tx.From()) {
-		return types.ErrInvalidSequence
+	if err := tx.Policy.Validate(); err != nil {
+		return err
 	}
 
 	fromAcc, err := loader.Account(tx.From())
@@ -62,7 +78,14 @@ func (tx *UpdatePolicy) Validate(p types.Process, loader types.LoaderWrapper, si
 func (tx *UpdatePolicy) Execute(p types.Process, ctw *types.ContextWrapper, index uint16) error {
 	sp := p.(*Gateway)
 
-	if err := sp.SetPolicy(ctw, tx.Platform, tx.Policy); err != nil {
+	current, err := sp.GetPolicy(ctw, tx.Platform)
+	if err != nil {
+		return err
+	}
+	// the pause state is only changed by SetPlatformPaused
+	policy := *tx.Policy
+	policy.IsPaused = current.IsPaused
+	if err := sp.SetPolicy(ctw, tx.Platform, &policy); err != nil {
 		return err
 	}
 	return nil
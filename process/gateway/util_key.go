@@ -10,9 +10,18 @@ var (
 	tagERC20TXID     = []byte{1, 0}
 	tagOutTXID       = []byte{1, 1}
 	tagPolicy        = []byte{2, 0}
+	tagCommittee     = []byte{2, 1}
 	tagPlatform      = []byte{3, 0}
 	tagPlatformIndex = []byte{3, 1}
 	tagPlatformCount = []byte{3, 2}
+
+	tagWithdrawal                = []byte{4, 0}
+	tagPendingWithdrawalCount    = []byte{4, 1}
+	tagPendingWithdrawalIndex    = []byte{4, 2}
+	tagPendingWithdrawalPosition = []byte{4, 3}
+
+	tagTokenInVolume  = []byte{5, 0}
+	tagTokenOutVolume = []byte{5, 1}
 )
 
 func toERC20TXIDKey(Platform string, h hash.Hash256) []byte {
@@ -51,3 +60,46 @@ func toPolicyKey(Platform string) []byte {
 	copy(bs[2:], []byte(Platform))
 	return bs
 }
+
+func toCommitteeKey(Platform string) []byte {
+	bs := make([]byte, 2+len(Platform))
+	copy(bs, tagCommittee)
+	copy(bs[2:], []byte(Platform))
+	return bs
+}
+
+func toWithdrawalKey(CoinTXID string) []byte {
+	bs := make([]byte, 2+len(CoinTXID))
+	copy(bs, tagWithdrawal)
+	copy(bs[2:], []byte(CoinTXID))
+	return bs
+}
+
+func toPendingWithdrawalCountKey(Platform string) []byte {
+	bs := make([]byte, 2+len(Platform))
+	copy(bs, tagPendingWithdrawalCount)
+	copy(bs[2:], []byte(Platform))
+	return bs
+}
+
+func toPendingWithdrawalIndexKey(Platform string, index uint32) []byte {
+	bs := make([]byte, 6+len(Platform))
+	copy(bs, tagPendingWithdrawalIndex)
+	binutil.BigEndian.PutUint32(bs[2:], index)
+	copy(bs[6:], []byte(Platform))
+	return bs
+}
+
+func toPendingWithdrawalPositionKey(CoinTXID string) []byte {
+	bs := make([]byte, 2+len(CoinTXID))
+	copy(bs, tagPendingWithdrawalPosition)
+	copy(bs[2:], []byte(CoinTXID))
+	return bs
+}
+
+func toVolumeKey(tag []byte, Platform string) []byte {
+	bs := make([]byte, 2+len(Platform))
+	copy(bs, tag)
+	copy(bs[2:], []byte(Platform))
+	return bs
+}
@@ -0,0 +1,45 @@
+package gateway
+
+import (
+	"github.com/fletaio/fleta/common/amount"
+	"github.com/fletaio/fleta/common/binutil"
+	"github.com/fletaio/fleta/core/types"
+)
+
+// DailyTokenInVolume returns the TokenIn volume of the platform in the current day window
+func (p *Gateway) DailyTokenInVolume(loader types.Loader, Platform string) *amount.Amount {
+	lw := types.NewLoaderWrapper(p.pid, loader)
+
+	return p.dailyVolume(lw, tagTokenInVolume, Platform)
+}
+
+// DailyTokenOutVolume returns the TokenOut volume of the platform in the current day window
+func (p *Gateway) DailyTokenOutVolume(loader types.Loader, Platform string) *amount.Amount {
+	lw := types.NewLoaderWrapper(p.pid, loader)
+
+	return p.dailyVolume(lw, tagTokenOutVolume, Platform)
+}
+
+func (p *Gateway) dailyVolume(lw types.LoaderWrapper, tag []byte, Platform string) *amount.Amount {
+	bs := lw.ProcessData(toVolumeKey(tag, Platform))
+	if len(bs) < 4 || binutil.BigEndian.Uint32(bs) != lw.TargetHeight()/BlocksPerDay {
+		return amount.NewCoinAmount(0, 0)
+	}
+	return amount.NewAmountFromBytes(bs[4:])
+}
+
+// addDailyVolume adds the amount to the volume of the current day window
+// the volume is only tracked when the daily limit is set
+func (p *Gateway) addDailyVolume(ctw *types.ContextWrapper, tag []byte, Platform string, Limit *amount.Amount, am *amount.Amount) error {
+	ctw = types.SwitchContextWrapper(p.pid, ctw)
+
+	if Limit == nil {
+		return nil
+	}
+	Volume := p.dailyVolume(ctw, tag, Platform).Add(am)
+	if Limit.Less(Volume) {
+		return ErrExceedDailyLimit
+	}
+	ctw.SetProcessData(toVolumeKey(tag, Platform), append(binutil.BigEndian.Uint32ToBytes(ctw.TargetHeight()/BlocksPerDay), Volume.Bytes()...))
+	return nil
+}
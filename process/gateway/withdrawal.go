@@ -0,0 +1,183 @@
+package gateway
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/fletaio/fleta/common"
+	"github.com/fletaio/fleta/common/amount"
+	"github.com/fletaio/fleta/common/binutil"
+	"github.com/fletaio/fleta/common/hash"
+	"github.com/fletaio/fleta/core/types"
+	"github.com/fletaio/fleta/encoding"
+)
+
+// withdrawal statuses
+const (
+	WithdrawalRequested  = uint8(1)
+	WithdrawalProcessing = uint8(2)
+	WithdrawalCompleted  = uint8(3)
+	WithdrawalRefunded   = uint8(4)
+)
+
+// Withdrawal is the state of the payout requested by the TokenOut transaction
+type Withdrawal struct {
+	Platform      string
+	CoinTXID      string
+	CoinFrom      common.Address
+	ERC20To       ERC20Address
+	Amount        *amount.Amount
+	Status        uint8
+	ERC20TXID     hash.Hash256
+	UpdatedHeight uint32
+}
+
+// MarshalJSON is a marshaler function
+func (wd *Withdrawal) MarshalJSON() ([]byte, error) {
+	var buffer bytes.Buffer
+	buffer.WriteString(`{`)
+	buffer.WriteString(`"platform":`)
+	if bs, err := json.Marshal(wd.Platform); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"coin_txid":`)
+	if bs, err := json.Marshal(wd.CoinTXID); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"coin_from":`)
+	if bs, err := wd.CoinFrom.MarshalJSON(); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"erc20_to":`)
+	if bs, err := wd.ERC20To.MarshalJSON(); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"amount":`)
+	if bs, err := wd.Amount.MarshalJSON(); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"status":`)
+	if bs, err := json.Marshal(wd.Status); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"erc20_txid":`)
+	if bs, err := wd.ERC20TXID.MarshalJSON(); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"updated_height":`)
+	if bs, err := json.Marshal(wd.UpdatedHeight); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`}`)
+	return buffer.Bytes(), nil
+}
+
+// IsPending returns true when the payout is not finished
+func (wd *Withdrawal) IsPending() bool {
+	return wd.Status == WithdrawalRequested || wd.Status == WithdrawalProcessing
+}
+
+// Withdrawal returns the withdrawal of the TokenOut transaction
+func (p *Gateway) Withdrawal(loader types.Loader, CoinTXID string) (*Withdrawal, error) {
+	lw := types.NewLoaderWrapper(p.pid, loader)
+
+	bs := lw.ProcessData(toWithdrawalKey(CoinTXID))
+	if len(bs) == 0 {
+		return nil, ErrNotExistWithdrawal
+	}
+	wd := &Withdrawal{}
+	if err := encoding.Unmarshal(bs, &wd); err != nil {
+		return nil, err
+	}
+	return wd, nil
+}
+
+// PendingWithdrawals returns withdrawals of the platform that are requested or processing
+func (p *Gateway) PendingWithdrawals(loader types.Loader, Platform string) ([]*Withdrawal, error) {
+	lw := types.NewLoaderWrapper(p.pid, loader)
+
+	Count := p.pendingWithdrawalCount(lw, Platform)
+	list := make([]*Withdrawal, 0, Count)
+	for i := uint32(0); i < Count; i++ {
+		wd, err := p.Withdrawal(lw, string(lw.ProcessData(toPendingWithdrawalIndexKey(Platform, i))))
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, wd)
+	}
+	return list, nil
+}
+
+// setWithdrawal stores the withdrawal and keeps the pending list of the platform
+func (p *Gateway) setWithdrawal(ctw *types.ContextWrapper, wd *Withdrawal) error {
+	ctw = types.SwitchContextWrapper(p.pid, ctw)
+
+	wasPending := false
+	if prev, err := p.Withdrawal(ctw, wd.CoinTXID); err != nil {
+		if err != ErrNotExistWithdrawal {
+			return err
+		}
+	} else {
+		wasPending = prev.IsPending()
+	}
+	wd.UpdatedHeight = ctw.TargetHeight()
+	bs, err := encoding.Marshal(wd)
+	if err != nil {
+		return err
+	}
+	ctw.SetProcessData(toWithdrawalKey(wd.CoinTXID), bs)
+
+	if !wasPending && wd.IsPending() {
+		Count := p.pendingWithdrawalCount(ctw, wd.Platform)
+		ctw.SetProcessData(toPendingWithdrawalIndexKey(wd.Platform, Count), []byte(wd.CoinTXID))
+		ctw.SetProcessData(toPendingWithdrawalPositionKey(wd.CoinTXID), binutil.BigEndian.Uint32ToBytes(Count))
+		ctw.SetProcessData(toPendingWithdrawalCountKey(wd.Platform), binutil.BigEndian.Uint32ToBytes(Count+1))
+	} else if wasPending && !wd.IsPending() {
+		pos := binutil.BigEndian.Uint32(ctw.ProcessData(toPendingWithdrawalPositionKey(wd.CoinTXID)))
+		Last := p.pendingWithdrawalCount(ctw, wd.Platform) - 1
+		if pos != Last {
+			LastTXID := ctw.ProcessData(toPendingWithdrawalIndexKey(wd.Platform, Last))
+			ctw.SetProcessData(toPendingWithdrawalIndexKey(wd.Platform, pos), LastTXID)
+			ctw.SetProcessData(toPendingWithdrawalPositionKey(string(LastTXID)), binutil.BigEndian.Uint32ToBytes(pos))
+		}
+		ctw.SetProcessData(toPendingWithdrawalIndexKey(wd.Platform, Last), nil)
+		ctw.SetProcessData(toPendingWithdrawalPositionKey(wd.CoinTXID), nil)
+		if Last == 0 {
+			ctw.SetProcessData(toPendingWithdrawalCountKey(wd.Platform), nil)
+		} else {
+			ctw.SetProcessData(toPendingWithdrawalCountKey(wd.Platform), binutil.BigEndian.Uint32ToBytes(Last))
+		}
+	}
+	return nil
+}
+
+func (p *Gateway) pendingWithdrawalCount(lw types.LoaderWrapper, Platform string) uint32 {
+	bs := lw.ProcessData(toPendingWithdrawalCountKey(Platform))
+	if len(bs) == 0 {
+		return 0
+	}
+	return binutil.BigEndian.Uint32(bs)
+}
@@ -0,0 +1,15 @@
+package governance
+
+import "errors"
+
+// errors
+var (
+	ErrInvalidActivationHeight    = errors.New("invalid activation height")
+	ErrNotGovernableTransaction   = errors.New("not governable transaction")
+	ErrMismatchPayloadTransaction = errors.New("mismatch payload transaction")
+	ErrNotExistProposal           = errors.New("not exist proposal")
+	ErrNotPendingProposal         = errors.New("not pending proposal")
+	ErrAlreadyVoted               = errors.New("already voted")
+	ErrInvalidVoter               = errors.New("invalid voter")
+	ErrInvalidPolicy              = errors.New("invalid policy")
+)
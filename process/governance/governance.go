@@ -0,0 +1,119 @@
+package governance
+
+import (
+	"github.com/fletaio/fleta/common"
+	"github.com/fletaio/fleta/core/types"
+	"github.com/fletaio/fleta/process/vault"
+	"github.com/fletaio/fleta/service/apiserver"
+)
+
+// Governance queues policy updates as proposals that formulators can veto until the activation height
+type Governance struct {
+	*types.ProcessBase
+	pid   uint8
+	pm    types.ProcessManager
+	cn    types.Provider
+	vault *vault.Vault
+}
+
+// governableTransaction defines common functions of policy update transactions that can be proposed
+type governableTransaction interface {
+	Seq() uint64
+	From() common.Address
+	ValidatePayload(p types.Process, loader types.LoaderWrapper, signers []common.PublicHash) error
+}
+
+// NewGovernance returns a Governance
+func NewGovernance(pid uint8) *Governance {
+	p := &Governance{
+		pid: pid,
+	}
+	return p
+}
+
+// ID returns the id of the process
+func (p *Governance) ID() uint8 {
+	return p.pid
+}
+
+// Name returns the name of the process
+func (p *Governance) Name() string {
+	return "fleta.governance"
+}
+
+// Version returns the version of the process
+func (p *Governance) Version() string {
+	return "0.0.1"
+}
+
+// Init initializes the process
+func (p *Governance) Init(reg *types.Register, pm types.ProcessManager, cn types.Provider) error {
+	p.pm = pm
+	p.cn = cn
+
+	if vp, err := pm.ProcessByName("fleta.vault"); err != nil {
+		return err
+	} else if v, is := vp.(*vault.Vault); !is {
+		return types.ErrInvalidProcess
+	} else {
+		p.vault = v
+	}
+
+	reg.RegisterTransaction(1, &Propose{})
+	reg.RegisterTransaction(2, &VoteVeto{})
+	reg.RegisterEvent(1, &ProposalEvent{})
+
+	if vs, err := pm.ServiceByName("fleta.apiserver"); err != nil {
+		//ignore when not loaded
+	} else if v, is := vs.(*apiserver.APIServer); !is {
+		//ignore when not loaded
+	} else {
+		s, err := v.JRPC("governance")
+		if err != nil {
+			return err
+		}
+		s.Set("policy", func(ID interface{}, arg *apiserver.Argument) (interface{}, error) {
+			loader := cn.NewLoaderWrapper(p.ID())
+			return p.GetPolicy(loader)
+		})
+		s.Set("proposal", func(ID interface{}, arg *apiserver.Argument) (interface{}, error) {
+			if arg.Len() != 1 {
+				return nil, apiserver.ErrInvalidArgument
+			}
+			ProposalID, err := arg.Uint64(0)
+			if err != nil {
+				return nil, err
+			}
+			loader := cn.NewLoaderWrapper(p.ID())
+			return p.Proposal(loader, ProposalID)
+		})
+		s.Set("pendingProposals", func(ID interface{}, arg *apiserver.Argument) (interface{}, error) {
+			loader := cn.NewLoaderWrapper(p.ID())
+			return p.PendingProposals(loader)
+		})
+	}
+	return nil
+}
+
+// OnLoadChain called when the chain loaded
+func (p *Governance) OnLoadChain(loader types.LoaderWrapper) error {
+	return nil
+}
+
+// BeforeExecuteTransactions called before processes transactions of the block
+func (p *Governance) BeforeExecuteTransactions(ctw *types.ContextWrapper) error {
+	if err := p.executeProposals(ctw); err != nil {
+		return err
+	}
+	return nil
+}
+
+// AfterExecuteTransactions called after processes transactions of the block
+func (p *Governance) AfterExecuteTransactions(b *types.Block, ctw *types.ContextWrapper) error {
+	return nil
+}
+
+// OnSaveData called when the context of the block saved
+func (p *Governance) OnSaveData(b *types.Block, ctw *types.ContextWrapper) error {
+	return nil
+}
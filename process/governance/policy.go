@@ -0,0 +1,86 @@
+package governance
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/fletaio/fleta/common/amount"
+	"github.com/fletaio/fleta/core/types"
+	"github.com/fletaio/fleta/encoding"
+)
+
+// Policy defines the time lock and the veto threshold of proposals
+type Policy struct {
+	MinActivationDelay uint32
+	VetoThreshold      *amount.Amount
+}
+
+// DefaultPolicy returns the policy used when the policy is not initialized at the genesis
+func DefaultPolicy() *Policy {
+	return &Policy{
+		MinActivationDelay: 172800,                            // a day
+		VetoThreshold:      amount.NewCoinAmount(30000000, 0), // 30,000,000 FLETA
+	}
+}
+
+// Validate checks the policy
+func (pc *Policy) Validate() error {
+	if pc.MinActivationDelay == 0 {
+		return ErrInvalidPolicy
+	}
+	if pc.VetoThreshold == nil || pc.VetoThreshold.IsZero() {
+		return ErrInvalidPolicy
+	}
+	return nil
+}
+
+// MarshalJSON is a marshaler function
+func (pc *Policy) MarshalJSON() ([]byte, error) {
+	var buffer bytes.Buffer
+	buffer.WriteString(`{`)
+	buffer.WriteString(`"min_activation_delay":`)
+	if bs, err := json.Marshal(pc.MinActivationDelay); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"veto_threshold":`)
+	if bs, err := pc.VetoThreshold.MarshalJSON(); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`}`)
+	return buffer.Bytes(), nil
+}
+
+// InitPolicy called at OnInitGenesis of an application
+func (p *Governance) InitPolicy(ctw *types.ContextWrapper, policy *Policy) error {
+	ctw = types.SwitchContextWrapper(p.pid, ctw)
+
+	if err := policy.Validate(); err != nil {
+		return err
+	}
+	bs, err := encoding.Marshal(policy)
+	if err != nil {
+		return err
+	}
+	ctw.SetProcessData(tagPolicy, bs)
+	return nil
+}
+
+// GetPolicy returns the policy of the governance
+func (p *Governance) GetPolicy(loader types.Loader) (*Policy, error) {
+	lw := types.NewLoaderWrapper(p.pid, loader)
+
+	bs := lw.ProcessData(tagPolicy)
+	if len(bs) == 0 {
+		return DefaultPolicy(), nil
+	}
+	policy := &Policy{}
+	if err := encoding.Unmarshal(bs, &policy); err != nil {
+		return nil, err
+	}
+	return policy, nil
+}
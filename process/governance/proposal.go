@@ -0,0 +1,323 @@
+package governance
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+
+	"github.com/fletaio/fleta/common"
+	"github.com/fletaio/fleta/common/amount"
+	"github.com/fletaio/fleta/common/binutil"
+	"github.com/fletaio/fleta/core/chain"
+	"github.com/fletaio/fleta/core/types"
+	"github.com/fletaio/fleta/encoding"
+	"github.com/fletaio/fleta/process/formulator"
+	"github.com/fletaio/fleta/process/gateway"
+	"github.com/fletaio/fleta/process/vault"
+)
+
+// proposal statuses
+const (
+	ProposalPending  = uint8(1)
+	ProposalVetoed   = uint8(2)
+	ProposalExecuted = uint8(3)
+	ProposalFailed   = uint8(4)
+)
+
+// Proposal is the policy update transaction queued until the activation height
+type Proposal struct {
+	ID               uint64
+	Proposer         common.Address
+	ProposedHeight   uint32
+	ProposedIndex    uint16
+	TxType           uint16
+	Payload          []byte
+	ActivationHeight uint32
+	VetoWeight       *amount.Amount
+	Status           uint8
+}
+
+// MarshalJSON is a marshaler function
+func (ps *Proposal) MarshalJSON() ([]byte, error) {
+	var buffer bytes.Buffer
+	buffer.WriteString(`{`)
+	buffer.WriteString(`"id":`)
+	if bs, err := json.Marshal(ps.ID); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"proposer":`)
+	if bs, err := ps.Proposer.MarshalJSON(); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"proposed_height":`)
+	if bs, err := json.Marshal(ps.ProposedHeight); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"proposed_index":`)
+	if bs, err := json.Marshal(ps.ProposedIndex); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"tx_type":`)
+	if bs, err := json.Marshal(ps.TxType); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"payload":`)
+	buffer.WriteString(`"`)
+	buffer.WriteString(hex.EncodeToString(ps.Payload))
+	buffer.WriteString(`"`)
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"activation_height":`)
+	if bs, err := json.Marshal(ps.ActivationHeight); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"veto_weight":`)
+	if bs, err := ps.VetoWeight.MarshalJSON(); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"status":`)
+	if bs, err := json.Marshal(ps.Status); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`}`)
+	return buffer.Bytes(), nil
+}
+
+// DecodePayload returns the policy update transaction of the payload and its process
+// only policy update transactions can be proposed
+func (p *Governance) DecodePayload(TxType uint16, Payload []byte) (types.Process, types.Transaction, error) {
+	v, err := encoding.Factory("transaction").Create(TxType)
+	if err != nil {
+		return nil, nil, err
+	}
+	switch v.(type) {
+	case *formulator.UpdateRewardPolicy:
+	case *formulator.UpdateHyperPolicy:
+	case *formulator.UpdateMiningFeePolicy:
+	case *formulator.UpdateConsensusPolicy:
+	case *formulator.UpdateTransmutePolicy:
+	case *vault.UpdatePolicy:
+	case *vault.UpdateDynamicFeePolicy:
+	case *vault.UpdateDefaultFee:
+	case *gateway.UpdatePolicy:
+	default:
+		return nil, nil, ErrNotGovernableTransaction
+	}
+	if err := encoding.Unmarshal(Payload, v); err != nil {
+		return nil, nil, err
+	}
+	tp, err := p.pm.Process(uint8(TxType >> 8))
+	if err != nil {
+		return nil, nil, err
+	}
+	return tp, v.(types.Transaction), nil
+}
+
+// Proposal returns the proposal
+func (p *Governance) Proposal(loader types.Loader, ID uint64) (*Proposal, error) {
+	lw := types.NewLoaderWrapper(p.pid, loader)
+
+	bs := lw.ProcessData(toProposalKey(ID))
+	if len(bs) == 0 {
+		return nil, ErrNotExistProposal
+	}
+	ps := &Proposal{}
+	if err := encoding.Unmarshal(bs, &ps); err != nil {
+		return nil, err
+	}
+	return ps, nil
+}
+
+// PendingProposals returns proposals that wait the activation height
+func (p *Governance) PendingProposals(loader types.Loader) ([]*Proposal, error) {
+	lw := types.NewLoaderWrapper(p.pid, loader)
+
+	IDs, err := p.pendingProposalIDs(lw)
+	if err != nil {
+		return nil, err
+	}
+	list := make([]*Proposal, 0, len(IDs))
+	for _, ID := range IDs {
+		ps, err := p.Proposal(loader, ID)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, ps)
+	}
+	return list, nil
+}
+
+func (p *Governance) pendingProposalIDs(lw types.LoaderWrapper) ([]uint64, error) {
+	IDs := []uint64{}
+	if bs := lw.ProcessData(tagPendingProposals); len(bs) > 0 {
+		if err := encoding.Unmarshal(bs, &IDs); err != nil {
+			return nil, err
+		}
+	}
+	return IDs, nil
+}
+
+func (p *Governance) setPendingProposalIDs(ctw *types.ContextWrapper, IDs []uint64) error {
+	if len(IDs) == 0 {
+		ctw.SetProcessData(tagPendingProposals, nil)
+		return nil
+	}
+	bs, err := encoding.Marshal(IDs)
+	if err != nil {
+		return err
+	}
+	ctw.SetProcessData(tagPendingProposals, bs)
+	return nil
+}
+
+func (p *Governance) nextProposalID(ctw *types.ContextWrapper) uint64 {
+	var ID uint64
+	if bs := ctw.ProcessData(tagProposalCount); len(bs) > 0 {
+		ID = binutil.BigEndian.Uint64(bs)
+	}
+	ID++
+	ctw.SetProcessData(tagProposalCount, binutil.BigEndian.Uint64ToBytes(ID))
+	return ID
+}
+
+// setProposal stores the proposal and keeps the pending list and the activation queue
+func (p *Governance) setProposal(ctw *types.ContextWrapper, ps *Proposal, IsNew bool) error {
+	bs, err := encoding.Marshal(ps)
+	if err != nil {
+		return err
+	}
+	ctw.SetProcessData(toProposalKey(ps.ID), bs)
+
+	IDs, err := p.pendingProposalIDs(ctw)
+	if err != nil {
+		return err
+	}
+	if IsNew {
+		if err := p.setPendingProposalIDs(ctw, append(IDs, ps.ID)); err != nil {
+			return err
+		}
+		qbs := ctw.ProcessData(toActivationQueueKey(ps.ActivationHeight))
+		ctw.SetProcessData(toActivationQueueKey(ps.ActivationHeight), append(qbs, binutil.BigEndian.Uint64ToBytes(ps.ID)...))
+	} else if ps.Status != ProposalPending {
+		for i, ID := range IDs {
+			if ID == ps.ID {
+				if err := p.setPendingProposalIDs(ctw, append(IDs[:i], IDs[i+1:]...)); err != nil {
+					return err
+				}
+				break
+			}
+		}
+	}
+	return nil
+}
+
+// proposalSigners returns signers of the propose transaction of the proposal
+func (p *Governance) proposalSigners(ps *Proposal) ([]common.PublicHash, error) {
+	b, err := p.cn.Block(ps.ProposedHeight)
+	if err != nil {
+		return nil, err
+	}
+	if int(ps.ProposedIndex) >= len(b.Transactions) {
+		return nil, ErrNotExistProposal
+	}
+	TxHash := chain.HashTransactionByType(p.cn.ChainID(), b.TransactionTypes[ps.ProposedIndex], b.Transactions[ps.ProposedIndex])
+	sigs := b.TransactionSignatures[ps.ProposedIndex]
+	signers := make([]common.PublicHash, 0, len(sigs))
+	for _, sig := range sigs {
+		pubkey, err := common.RecoverPubkey(TxHash, sig)
+		if err != nil {
+			return nil, err
+		}
+		signers = append(signers, common.NewPublicHash(pubkey))
+	}
+	return signers, nil
+}
+
+// executeProposals executes payloads of pending proposals that reach the activation height
+// payloads are validated again with signers of the proposal so a revoked role or a transferred admin fails them
+// a failed payload does not stop the block, it is recorded as ProposalFailed
+func (p *Governance) executeProposals(ctw *types.ContextWrapper) error {
+	ctw = types.SwitchContextWrapper(p.pid, ctw)
+
+	Height := ctw.TargetHeight()
+	bs := ctw.ProcessData(toActivationQueueKey(Height))
+	if len(bs) == 0 {
+		return nil
+	}
+	ctw.SetProcessData(toActivationQueueKey(Height), nil)
+
+	for i := 0; i+8 <= len(bs); i += 8 {
+		ps, err := p.Proposal(ctw, binutil.BigEndian.Uint64(bs[i:]))
+		if err != nil {
+			return err
+		}
+		if ps.Status != ProposalPending {
+			continue
+		}
+		signers, err := p.proposalSigners(ps)
+		if err != nil {
+			return err
+		}
+		ps.Status = ProposalExecuted
+		if tp, tx, err := p.DecodePayload(ps.TxType, ps.Payload); err != nil {
+			ps.Status = ProposalFailed
+		} else if gt, is := tx.(governableTransaction); !is {
+			ps.Status = ProposalFailed
+		} else if err := gt.ValidatePayload(tp, types.NewLoaderWrapper(tp.ID(), ctw), signers); err != nil {
+			ps.Status = ProposalFailed
+		} else {
+			tctw := types.SwitchContextWrapper(tp.ID(), ctw)
+			sn := tctw.Snapshot()
+			if err := tx.Execute(tp, tctw, 65535); err != nil {
+				tctw.Revert(sn)
+				ps.Status = ProposalFailed
+			} else {
+				tctw.Commit(sn)
+			}
+		}
+		if err := p.setProposal(ctw, ps, false); err != nil {
+			return err
+		}
+		if err := ctw.EmitEvent(&ProposalEvent{
+			Height_:    Height,
+			Index_:     65535,
+			ProposalID: ps.ID,
+			Status:     ps.Status,
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// VoteWeight returns the veto weight of the formulator account
+// it is the creation amount of the formulator and the staked amount of the hyper formulator
+func VoteWeight(acc *formulator.FormulatorAccount) *amount.Amount {
+	weight := acc.Amount.Clone()
+	if acc.FormulatorType == formulator.HyperFormulatorType && acc.StakingAmount != nil {
+		weight = weight.Add(acc.StakingAmount)
+	}
+	return weight
+}
@@ -0,0 +1,143 @@
+package governance
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+
+	"github.com/fletaio/fleta/common"
+	"github.com/fletaio/fleta/common/amount"
+	"github.com/fletaio/fleta/core/types"
+)
+
+// Propose queues the policy update transaction of the payload until the activation height
+// the payload should be signed with the same from address and sequence of the proposal
+type Propose struct {
+	Timestamp_       uint64
+	Seq_             uint64
+	From_            common.Address
+	TxType           uint16
+	Payload          []byte
+	ActivationHeight uint32
+}
+
+// Timestamp returns the timestamp of the transaction
+func (tx *Propose) Timestamp() uint64 {
+	return tx.Timestamp_
+}
+
+// Seq returns the sequence of the transaction
+func (tx *Propose) Seq() uint64 {
+	return tx.Seq_
+}
+
+// From returns the from address of the transaction
+func (tx *Propose) From() common.Address {
+	return tx.From_
+}
+
+// Validate validates signatures of the transaction
+func (tx *Propose) Validate(p types.Process, loader types.LoaderWrapper, signers []common.PublicHash) error {
+	sp := p.(*Governance)
+
+	policy, err := sp.GetPolicy(loader)
+	if err != nil {
+		return err
+	}
+	if tx.ActivationHeight < loader.TargetHeight()+policy.MinActivationDelay {
+		return ErrInvalidActivationHeight
+	}
+
+	if tx.Seq() <= loader.Seq(tx.From()) {
+		return types.ErrInvalidSequence
+	}
+
+	tp, ptx, err := sp.DecodePayload(tx.TxType, tx.Payload)
+	if err != nil {
+		return err
+	}
+	gt, is := ptx.(governableTransaction)
+	if !is {
+		return ErrNotGovernableTransaction
+	}
+	if gt.From() != tx.From() || gt.Seq() != tx.Seq() {
+		return ErrMismatchPayloadTransaction
+	}
+	if err := gt.ValidatePayload(tp, types.NewLoaderWrapper(tp.ID(), loader), signers); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Execute updates the context by the transaction
+func (tx *Propose) Execute(p types.Process, ctw *types.ContextWrapper, index uint16) error {
+	sp := p.(*Governance)
+
+	ps := &Proposal{
+		ID:               sp.nextProposalID(ctw),
+		Proposer:         tx.From(),
+		ProposedHeight:   ctw.TargetHeight(),
+		ProposedIndex:    index,
+		TxType:           tx.TxType,
+		Payload:          tx.Payload,
+		ActivationHeight: tx.ActivationHeight,
+		VetoWeight:       amount.NewCoinAmount(0, 0),
+		Status:           ProposalPending,
+	}
+	if err := sp.setProposal(ctw, ps, true); err != nil {
+		return err
+	}
+	return ctw.EmitEvent(&ProposalEvent{
+		Height_:    ctw.TargetHeight(),
+		Index_:     index,
+		ProposalID: ps.ID,
+		Status:     ps.Status,
+	})
+}
+
+// MarshalJSON is a marshaler function
+func (tx *Propose) MarshalJSON() ([]byte, error) {
+	var buffer bytes.Buffer
+	buffer.WriteString(`{`)
+	buffer.WriteString(`"timestamp":`)
+	if bs, err := json.Marshal(tx.Timestamp_); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"seq":`)
+	if bs, err := json.Marshal(tx.Seq_); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"from":`)
+	if bs, err := tx.From_.MarshalJSON(); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"tx_type":`)
+	if bs, err := json.Marshal(tx.TxType); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"payload":`)
+	buffer.WriteString(`"`)
+	buffer.WriteString(hex.EncodeToString(tx.Payload))
+	buffer.WriteString(`"`)
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"activation_height":`)
+	if bs, err := json.Marshal(tx.ActivationHeight); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`}`)
+	return buffer.Bytes(), nil
+}
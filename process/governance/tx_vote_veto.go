@@ -0,0 +1,155 @@
+package governance
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/fletaio/fleta/common"
+	"github.com/fletaio/fleta/common/amount"
+	"github.com/fletaio/fleta/core/types"
+	"github.com/fletaio/fleta/process/formulator"
+)
+
+// VoteVeto adds the weight of the formulator to the veto of the pending proposal
+// the proposal is vetoed when the veto weight reaches the threshold of the policy
+type VoteVeto struct {
+	Timestamp_ uint64
+	Seq_       uint64
+	From_      common.Address
+	ProposalID uint64
+}
+
+// Timestamp returns the timestamp of the transaction
+func (tx *VoteVeto) Timestamp() uint64 {
+	return tx.Timestamp_
+}
+
+// Seq returns the sequence of the transaction
+func (tx *VoteVeto) Seq() uint64 {
+	return tx.Seq_
+}
+
+// From returns the from address of the transaction
+func (tx *VoteVeto) From() common.Address {
+	return tx.From_
+}
+
+// Fee returns the fee of the transaction
+func (tx *VoteVeto) Fee(p types.Process, loader types.LoaderWrapper) *amount.Amount {
+	sp := p.(*Governance)
+	return sp.vault.GetDefaultFee(loader)
+}
+
+// Validate validates signatures of the transaction
+func (tx *VoteVeto) Validate(p types.Process, loader types.LoaderWrapper, signers []common.PublicHash) error {
+	sp := p.(*Governance)
+
+	if tx.Seq() <= loader.Seq(tx.From()) {
+		return types.ErrInvalidSequence
+	}
+
+	ps, err := sp.Proposal(loader, tx.ProposalID)
+	if err != nil {
+		return err
+	}
+	if ps.Status != ProposalPending || ps.ActivationHeight <= loader.TargetHeight() {
+		return ErrNotPendingProposal
+	}
+	if bs := loader.AccountData(tx.From(), toVoteKey(tx.ProposalID)); len(bs) > 0 {
+		return ErrAlreadyVoted
+	}
+
+	acc, err := loader.Account(tx.From())
+	if err != nil {
+		return err
+	}
+	frAcc, is := acc.(*formulator.FormulatorAccount)
+	if !is || frAcc.IsRevoked {
+		return ErrInvalidVoter
+	}
+	if err := frAcc.Validate(loader, signers); err != nil {
+		return err
+	}
+
+	if err := sp.vault.CheckFeePayable(p, loader, tx); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Execute updates the context by the transaction
+func (tx *VoteVeto) Execute(p types.Process, ctw *types.ContextWrapper, index uint16) error {
+	sp := p.(*Governance)
+
+	return sp.vault.WithFee(p, ctw, tx, func() error {
+		ps, err := sp.Proposal(ctw, tx.ProposalID)
+		if err != nil {
+			return err
+		}
+		acc, err := ctw.Account(tx.From())
+		if err != nil {
+			return err
+		}
+		frAcc, is := acc.(*formulator.FormulatorAccount)
+		if !is {
+			return ErrInvalidVoter
+		}
+		ctw.SetAccountData(tx.From(), toVoteKey(tx.ProposalID), []byte{1})
+
+		ps.VetoWeight = ps.VetoWeight.Add(VoteWeight(frAcc))
+		policy, err := sp.GetPolicy(ctw)
+		if err != nil {
+			return err
+		}
+		if !ps.VetoWeight.Less(policy.VetoThreshold) {
+			ps.Status = ProposalVetoed
+		}
+		if err := sp.setProposal(ctw, ps, false); err != nil {
+			return err
+		}
+		if ps.Status == ProposalVetoed {
+			return ctw.EmitEvent(&ProposalEvent{
+				Height_:    ctw.TargetHeight(),
+				Index_:     index,
+				ProposalID: ps.ID,
+				Status:     ps.Status,
+			})
+		}
+		return nil
+	})
+}
+
+// MarshalJSON is a marshaler function
+func (tx *VoteVeto) MarshalJSON() ([]byte, error) {
+	var buffer bytes.Buffer
+	buffer.WriteString(`{`)
+	buffer.WriteString(`"timestamp":`)
+	if bs, err := json.Marshal(tx.Timestamp_); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"seq":`)
+	if bs, err := json.Marshal(tx.Seq_); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"from":`)
+	if bs, err := tx.From_.MarshalJSON(); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"proposal_id":`)
+	if bs, err := json.Marshal(tx.ProposalID); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`}`)
+	return buffer.Bytes(), nil
+}
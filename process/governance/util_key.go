@@ -0,0 +1,36 @@
+package governance
+
+import (
+	"github.com/fletaio/fleta/common/binutil"
+)
+
+// tags
+var (
+	tagPolicy           = []byte{1, 0}
+	tagProposal         = []byte{2, 0}
+	tagProposalCount    = []byte{2, 1}
+	tagPendingProposals = []byte{2, 2}
+	tagActivationQueue  = []byte{3, 0}
+	tagVote             = []byte{4, 0}
+)
+
+func toProposalKey(ID uint64) []byte {
+	bs := make([]byte, 10)
+	copy(bs, tagProposal)
+	binutil.BigEndian.PutUint64(bs[2:], ID)
+	return bs
+}
+
+func toActivationQueueKey(Height uint32) []byte {
+	bs := make([]byte, 6)
+	copy(bs, tagActivationQueue)
+	binutil.BigEndian.PutUint32(bs[2:], Height)
+	return bs
+}
+
+func toVoteKey(ID uint64) []byte {
+	bs := make([]byte, 10)
+	copy(bs, tagVote)
+	binutil.BigEndian.PutUint64(bs[2:], ID)
+	return bs
+}
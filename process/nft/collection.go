@@ -0,0 +1,128 @@
+package nft
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/fletaio/fleta/common"
+	"github.com/fletaio/fleta/common/hash"
+)
+
+// Collection is the group of non-fungible tokens minted by the creator
+// zero MaxSupply means that the supply is unlimited
+type Collection struct {
+	CollectionID string
+	Name         string
+	URI          string
+	Creator      common.Address
+	MaxSupply    uint64
+	MintCount    uint64
+	Supply       uint64
+}
+
+// MarshalJSON is a marshaler function
+func (cl *Collection) MarshalJSON() ([]byte, error) {
+	var buffer bytes.Buffer
+	buffer.WriteString(`{`)
+	buffer.WriteString(`"collection_id":`)
+	if bs, err := json.Marshal(cl.CollectionID); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"name":`)
+	if bs, err := json.Marshal(cl.Name); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"uri":`)
+	if bs, err := json.Marshal(cl.URI); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"creator":`)
+	if bs, err := cl.Creator.MarshalJSON(); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"max_supply":`)
+	if bs, err := json.Marshal(cl.MaxSupply); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"mint_count":`)
+	if bs, err := json.Marshal(cl.MintCount); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"supply":`)
+	if bs, err := json.Marshal(cl.Supply); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`}`)
+	return buffer.Bytes(), nil
+}
+
+// Token is the non-fungible token of the collection
+type Token struct {
+	CollectionID string
+	TokenID      uint64
+	Owner        common.Address
+	URI          string
+	MetadataHash hash.Hash256
+}
+
+// MarshalJSON is a marshaler function
+func (nt *Token) MarshalJSON() ([]byte, error) {
+	var buffer bytes.Buffer
+	buffer.WriteString(`{`)
+	buffer.WriteString(`"collection_id":`)
+	if bs, err := json.Marshal(nt.CollectionID); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"token_id":`)
+	if bs, err := json.Marshal(nt.TokenID); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"owner":`)
+	if bs, err := nt.Owner.MarshalJSON(); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"uri":`)
+	if bs, err := json.Marshal(nt.URI); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"metadata_hash":`)
+	if bs, err := nt.MetadataHash.MarshalJSON(); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`}`)
+	return buffer.Bytes(), nil
+}
@@ -0,0 +1,16 @@
+package nft
+
+import "errors"
+
+// errors
+var (
+	ErrInvalidCollectionName = errors.New("invalid collection name")
+	ErrInvalidURI            = errors.New("invalid uri")
+	ErrNotExistCollection    = errors.New("not exist collection")
+	ErrNotExistToken         = errors.New("not exist token")
+	ErrNotCollectionCreator  = errors.New("not collection creator")
+	ErrNotTokenOwner         = errors.New("not token owner")
+	ErrExceedMaxSupply       = errors.New("exceed max supply")
+	ErrSelfTransfer          = errors.New("self transfer")
+	ErrInvalidPolicy         = errors.New("invalid policy")
+)
@@ -0,0 +1,240 @@
+package nft
+
+import (
+	"github.com/fletaio/fleta/common"
+	"github.com/fletaio/fleta/common/binutil"
+	"github.com/fletaio/fleta/core/types"
+	"github.com/fletaio/fleta/encoding"
+)
+
+// Collection returns the collection of the id
+func (p *NFT) Collection(loader types.Loader, CollectionID string) (*Collection, error) {
+	lw := types.NewLoaderWrapper(p.pid, loader)
+
+	key, err := toCollectionKey(CollectionID)
+	if err != nil {
+		return nil, err
+	}
+	bs := lw.ProcessData(key)
+	if len(bs) == 0 {
+		return nil, ErrNotExistCollection
+	}
+	cl := &Collection{}
+	if err := encoding.Unmarshal(bs, &cl); err != nil {
+		return nil, err
+	}
+	return cl, nil
+}
+
+func (p *NFT) setCollection(ctw *types.ContextWrapper, cl *Collection) error {
+	ctw = types.SwitchContextWrapper(p.pid, ctw)
+
+	key, err := toCollectionKey(cl.CollectionID)
+	if err != nil {
+		return err
+	}
+	bs, err := encoding.Marshal(cl)
+	if err != nil {
+		return err
+	}
+	ctw.SetProcessData(key, bs)
+	return nil
+}
+
+// CollectionCount returns the number of created collections
+func (p *NFT) CollectionCount(loader types.Loader) uint32 {
+	lw := types.NewLoaderWrapper(p.pid, loader)
+
+	if bs := lw.ProcessData(tagCollectionCount); len(bs) > 0 {
+		return binutil.BigEndian.Uint32(bs)
+	}
+	return 0
+}
+
+// Collections returns created collections in the creation order
+func (p *NFT) Collections(loader types.Loader) ([]*Collection, error) {
+	lw := types.NewLoaderWrapper(p.pid, loader)
+
+	Count := p.CollectionCount(lw)
+	list := make([]*Collection, 0, Count)
+	for i := uint32(0); i < Count; i++ {
+		cl, err := p.Collection(lw, string(lw.ProcessData(toCollectionIndexKey(i))))
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, cl)
+	}
+	return list, nil
+}
+
+func (p *NFT) addCollection(ctw *types.ContextWrapper, cl *Collection) error {
+	ctw = types.SwitchContextWrapper(p.pid, ctw)
+
+	if err := p.setCollection(ctw, cl); err != nil {
+		return err
+	}
+	Count := p.CollectionCount(ctw)
+	ctw.SetProcessData(toCollectionIndexKey(Count), []byte(cl.CollectionID))
+	ctw.SetProcessData(tagCollectionCount, binutil.BigEndian.Uint32ToBytes(Count+1))
+	return nil
+}
+
+// Token returns the token of the collection
+func (p *NFT) Token(loader types.Loader, CollectionID string, TokenID uint64) (*Token, error) {
+	lw := types.NewLoaderWrapper(p.pid, loader)
+
+	NFTID, err := toNFTID(CollectionID, TokenID)
+	if err != nil {
+		return nil, err
+	}
+	return p.tokenByID(lw, NFTID)
+}
+
+func (p *NFT) tokenByID(lw types.LoaderWrapper, NFTID []byte) (*Token, error) {
+	bs := lw.ProcessData(toTokenKey(NFTID))
+	if len(bs) == 0 {
+		return nil, ErrNotExistToken
+	}
+	nt := &Token{}
+	if err := encoding.Unmarshal(bs, &nt); err != nil {
+		return nil, err
+	}
+	return nt, nil
+}
+
+// CollectionTokens returns tokens of the collection from the token id
+// burned tokens are skipped
+func (p *NFT) CollectionTokens(loader types.Loader, CollectionID string, From uint64, Count int) ([]*Token, error) {
+	lw := types.NewLoaderWrapper(p.pid, loader)
+
+	cl, err := p.Collection(lw, CollectionID)
+	if err != nil {
+		return nil, err
+	}
+	if From < 1 {
+		From = 1
+	}
+	list := []*Token{}
+	for TokenID := From; TokenID <= cl.MintCount && len(list) < Count; TokenID++ {
+		nt, err := p.Token(lw, CollectionID, TokenID)
+		if err != nil {
+			if err == ErrNotExistToken {
+				continue
+			}
+			return nil, err
+		}
+		list = append(list, nt)
+	}
+	return list, nil
+}
+
+// OwnedCount returns the number of tokens owned by the address
+func (p *NFT) OwnedCount(loader types.Loader, Owner common.Address) uint32 {
+	lw := types.NewLoaderWrapper(p.pid, loader)
+
+	if bs := lw.AccountData(Owner, tagOwnedCount); len(bs) > 0 {
+		return binutil.BigEndian.Uint32(bs)
+	}
+	return 0
+}
+
+// OwnedTokens returns tokens owned by the address
+func (p *NFT) OwnedTokens(loader types.Loader, Owner common.Address) ([]*Token, error) {
+	lw := types.NewLoaderWrapper(p.pid, loader)
+
+	Count := p.OwnedCount(lw, Owner)
+	list := make([]*Token, 0, Count)
+	for i := uint32(0); i < Count; i++ {
+		nt, err := p.tokenByID(lw, lw.AccountData(Owner, toOwnedIndexKey(i)))
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, nt)
+	}
+	return list, nil
+}
+
+func (p *NFT) setToken(ctw *types.ContextWrapper, nt *Token) error {
+	ctw = types.SwitchContextWrapper(p.pid, ctw)
+
+	NFTID, err := toNFTID(nt.CollectionID, nt.TokenID)
+	if err != nil {
+		return err
+	}
+	if prev, err := p.tokenByID(ctw, NFTID); err != nil {
+		if err != ErrNotExistToken {
+			return err
+		}
+	} else if prev.Owner != nt.Owner {
+		p.removeOwned(ctw, prev.Owner, NFTID)
+		p.addOwned(ctw, nt.Owner, NFTID)
+	}
+	bs, err := encoding.Marshal(nt)
+	if err != nil {
+		return err
+	}
+	ctw.SetProcessData(toTokenKey(NFTID), bs)
+	return nil
+}
+
+func (p *NFT) addToken(ctw *types.ContextWrapper, nt *Token) error {
+	ctw = types.SwitchContextWrapper(p.pid, ctw)
+
+	NFTID, err := toNFTID(nt.CollectionID, nt.TokenID)
+	if err != nil {
+		return err
+	}
+	if err := p.setToken(ctw, nt); err != nil {
+		return err
+	}
+	p.addOwned(ctw, nt.Owner, NFTID)
+	return nil
+}
+
+func (p *NFT) removeToken(ctw *types.ContextWrapper, nt *Token) error {
+	ctw = types.SwitchContextWrapper(p.pid, ctw)
+
+	NFTID, err := toNFTID(nt.CollectionID, nt.TokenID)
+	if err != nil {
+		return err
+	}
+	p.removeOwned(ctw, nt.Owner, NFTID)
+	ctw.SetProcessData(toTokenKey(NFTID), nil)
+	return nil
+}
+
+func (p *NFT) addOwned(ctw *types.ContextWrapper, Owner common.Address, NFTID []byte) {
+	Count := p.OwnedCount(ctw, Owner)
+	ctw.SetAccountData(Owner, toOwnedIndexKey(Count), NFTID)
+	ctw.SetAccountData(Owner, toOwnedPositionKey(NFTID), binutil.BigEndian.Uint32ToBytes(Count))
+	ctw.SetAccountData(Owner, tagOwnedCount, binutil.BigEndian.Uint32ToBytes(Count+1))
+}
+
+func (p *NFT) removeOwned(ctw *types.ContextWrapper, Owner common.Address, NFTID []byte) {
+	bs := ctw.AccountData(Owner, toOwnedPositionKey(NFTID))
+	if len(bs) == 0 {
+		return
+	}
+	pos := binutil.BigEndian.Uint32(bs)
+	Last := p.OwnedCount(ctw, Owner) - 1
+	if pos != Last {
+		LastID := ctw.AccountData(Owner, toOwnedIndexKey(Last))
+		ctw.SetAccountData(Owner, toOwnedIndexKey(pos), LastID)
+		ctw.SetAccountData(Owner, toOwnedPositionKey(LastID), binutil.BigEndian.Uint32ToBytes(pos))
+	}
+	ctw.SetAccountData(Owner, toOwnedIndexKey(Last), nil)
+	ctw.SetAccountData(Owner, toOwnedPositionKey(NFTID), nil)
+	if Last == 0 {
+		ctw.SetAccountData(Owner, tagOwnedCount, nil)
+	} else {
+		ctw.SetAccountData(Owner, tagOwnedCount, binutil.BigEndian.Uint32ToBytes(Last))
+	}
+}
+
+// ValidateURI checks the length of the uri
+func ValidateURI(URI string) error {
+	if len(URI) > 256 {
+		return ErrInvalidURI
+	}
+	return nil
+}
@@ -0,0 +1,158 @@
+package nft
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/fletaio/fleta/common"
+	"github.com/fletaio/fleta/common/amount"
+	"github.com/fletaio/fleta/core/types"
+)
+
+// CreateCollection creates the collection that only the creator can mint
+// the id of the collection is the transaction id of it
+type CreateCollection struct {
+	Timestamp_ uint64
+	Seq_       uint64
+	From_      common.Address
+	Name       string
+	URI        string
+	MaxSupply  uint64
+}
+
+// Timestamp returns the timestamp of the transaction
+func (tx *CreateCollection) Timestamp() uint64 {
+	return tx.Timestamp_
+}
+
+// Seq returns the sequence of the transaction
+func (tx *CreateCollection) Seq() uint64 {
+	return tx.Seq_
+}
+
+// From returns the from address of the transaction
+func (tx *CreateCollection) From() common.Address {
+	return tx.From_
+}
+
+// Fee returns the fee of the transaction
+func (tx *CreateCollection) Fee(p types.Process, loader types.LoaderWrapper) *amount.Amount {
+	sp := p.(*NFT)
+	return sp.vault.GetDefaultFee(loader)
+}
+
+// Validate validates signatures of the transaction
+func (tx *CreateCollection) Validate(p types.Process, loader types.LoaderWrapper, signers []common.PublicHash) error {
+	sp := p.(*NFT)
+
+	if len(tx.Name) < 1 || len(tx.Name) > 64 {
+		return ErrInvalidCollectionName
+	}
+	if err := ValidateURI(tx.URI); err != nil {
+		return err
+	}
+	if tx.Seq() <= loader.Seq(tx.From()) {
+		return types.ErrInvalidSequence
+	}
+
+	fromAcc, err := loader.Account(tx.From())
+	if err != nil {
+		return err
+	}
+	if err := fromAcc.Validate(loader, signers); err != nil {
+		return err
+	}
+
+	policy, err := sp.GetPolicy(loader)
+	if err != nil {
+		return err
+	}
+	if err := sp.vault.CheckFeePayableWith(p, loader, tx, policy.CreationCost); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Execute updates the context by the transaction
+func (tx *CreateCollection) Execute(p types.Process, ctw *types.ContextWrapper, index uint16) error {
+	sp := p.(*NFT)
+
+	return sp.vault.WithFee(p, ctw, tx, func() error {
+		policy, err := sp.GetPolicy(ctw)
+		if err != nil {
+			return err
+		}
+		if err := sp.vault.SubBalance(ctw, tx.From(), policy.CreationCost); err != nil {
+			return err
+		}
+		if err := sp.vault.AddCollectedFee(ctw, policy.CreationCost); err != nil {
+			return err
+		}
+
+		CollectionID := types.TransactionID(ctw.TargetHeight(), index)
+		if err := sp.addCollection(ctw, &Collection{
+			CollectionID: CollectionID,
+			Name:         tx.Name,
+			URI:          tx.URI,
+			Creator:      tx.From(),
+			MaxSupply:    tx.MaxSupply,
+		}); err != nil {
+			return err
+		}
+		return ctw.EmitEvent(&CollectionCreatedEvent{
+			Height_:      ctw.TargetHeight(),
+			Index_:       index,
+			CollectionID: CollectionID,
+			Creator:      tx.From(),
+		})
+	})
+}
+
+// MarshalJSON is a marshaler function
+func (tx *CreateCollection) MarshalJSON() ([]byte, error) {
+	var buffer bytes.Buffer
+	buffer.WriteString(`{`)
+	buffer.WriteString(`"timestamp":`)
+	if bs, err := json.Marshal(tx.Timestamp_); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"seq":`)
+	if bs, err := json.Marshal(tx.Seq_); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"from":`)
+	if bs, err := tx.From_.MarshalJSON(); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"name":`)
+	if bs, err := json.Marshal(tx.Name); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"uri":`)
+	if bs, err := json.Marshal(tx.URI); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"max_supply":`)
+	if bs, err := json.Marshal(tx.MaxSupply); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`}`)
+	return buffer.Bytes(), nil
+}
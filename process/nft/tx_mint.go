@@ -0,0 +1,176 @@
+package nft
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/fletaio/fleta/common"
+	"github.com/fletaio/fleta/common/amount"
+	"github.com/fletaio/fleta/common/hash"
+	"github.com/fletaio/fleta/core/types"
+)
+
+// Mint issues the token of the collection to the address by the creator of the collection
+// the token id is assigned sequentially from 1
+type Mint struct {
+	Timestamp_   uint64
+	Seq_         uint64
+	From_        common.Address
+	CollectionID string
+	To           common.Address
+	URI          string
+	MetadataHash hash.Hash256
+}
+
+// Timestamp returns the timestamp of the transaction
+func (tx *Mint) Timestamp() uint64 {
+	return tx.Timestamp_
+}
+
+// Seq returns the sequence of the transaction
+func (tx *Mint) Seq() uint64 {
+	return tx.Seq_
+}
+
+// From returns the from address of the transaction
+func (tx *Mint) From() common.Address {
+	return tx.From_
+}
+
+// Fee returns the fee of the transaction
+func (tx *Mint) Fee(p types.Process, loader types.LoaderWrapper) *amount.Amount {
+	sp := p.(*NFT)
+	return sp.vault.GetDefaultFee(loader)
+}
+
+// Validate validates signatures of the transaction
+func (tx *Mint) Validate(p types.Process, loader types.LoaderWrapper, signers []common.PublicHash) error {
+	sp := p.(*NFT)
+
+	if err := ValidateURI(tx.URI); err != nil {
+		return err
+	}
+	cl, err := sp.Collection(loader, tx.CollectionID)
+	if err != nil {
+		return err
+	}
+	if cl.Creator != tx.From() {
+		return ErrNotCollectionCreator
+	}
+	if cl.MaxSupply > 0 && cl.MintCount >= cl.MaxSupply {
+		return ErrExceedMaxSupply
+	}
+	if has, err := loader.HasAccount(tx.To); err != nil {
+		return err
+	} else if !has {
+		return types.ErrNotExistAccount
+	}
+	if tx.Seq() <= loader.Seq(tx.From()) {
+		return types.ErrInvalidSequence
+	}
+
+	fromAcc, err := loader.Account(tx.From())
+	if err != nil {
+		return err
+	}
+	if err := fromAcc.Validate(loader, signers); err != nil {
+		return err
+	}
+
+	if err := sp.vault.CheckFeePayable(p, loader, tx); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Execute updates the context by the transaction
+func (tx *Mint) Execute(p types.Process, ctw *types.ContextWrapper, index uint16) error {
+	sp := p.(*NFT)
+
+	return sp.vault.WithFee(p, ctw, tx, func() error {
+		cl, err := sp.Collection(ctw, tx.CollectionID)
+		if err != nil {
+			return err
+		}
+		if cl.MaxSupply > 0 && cl.MintCount >= cl.MaxSupply {
+			return ErrExceedMaxSupply
+		}
+		cl.MintCount++
+		cl.Supply++
+		if err := sp.setCollection(ctw, cl); err != nil {
+			return err
+		}
+		if err := sp.addToken(ctw, &Token{
+			CollectionID: tx.CollectionID,
+			TokenID:      cl.MintCount,
+			Owner:        tx.To,
+			URI:          tx.URI,
+			MetadataHash: tx.MetadataHash,
+		}); err != nil {
+			return err
+		}
+		return ctw.EmitEvent(&MintedEvent{
+			Height_:      ctw.TargetHeight(),
+			Index_:       index,
+			CollectionID: tx.CollectionID,
+			TokenID:      cl.MintCount,
+			To:           tx.To,
+		})
+	})
+}
+
+// MarshalJSON is a marshaler function
+func (tx *Mint) MarshalJSON() ([]byte, error) {
+	var buffer bytes.Buffer
+	buffer.WriteString(`{`)
+	buffer.WriteString(`"timestamp":`)
+	if bs, err := json.Marshal(tx.Timestamp_); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"seq":`)
+	if bs, err := json.Marshal(tx.Seq_); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"from":`)
+	if bs, err := tx.From_.MarshalJSON(); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"collection_id":`)
+	if bs, err := json.Marshal(tx.CollectionID); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"to":`)
+	if bs, err := tx.To.MarshalJSON(); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"uri":`)
+	if bs, err := json.Marshal(tx.URI); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"metadata_hash":`)
+	if bs, err := tx.MetadataHash.MarshalJSON(); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`}`)
+	return buffer.Bytes(), nil
+}
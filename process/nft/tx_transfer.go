@@ -0,0 +1,154 @@
+package nft
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/fletaio/fleta/common"
+	"github.com/fletaio/fleta/common/amount"
+	"github.com/fletaio/fleta/core/types"
+)
+
+// Transfer sends the token to the address by the owner of the token
+type Transfer struct {
+	Timestamp_   uint64
+	Seq_         uint64
+	From_        common.Address
+	CollectionID string
+	TokenID      uint64
+	To           common.Address
+}
+
+// Timestamp returns the timestamp of the transaction
+func (tx *Transfer) Timestamp() uint64 {
+	return tx.Timestamp_
+}
+
+// Seq returns the sequence of the transaction
+func (tx *Transfer) Seq() uint64 {
+	return tx.Seq_
+}
+
+// From returns the from address of the transaction
+func (tx *Transfer) From() common.Address {
+	return tx.From_
+}
+
+// Fee returns the fee of the transaction
+func (tx *Transfer) Fee(p types.Process, loader types.LoaderWrapper) *amount.Amount {
+	sp := p.(*NFT)
+	return sp.vault.GetDefaultFee(loader)
+}
+
+// Validate validates signatures of the transaction
+func (tx *Transfer) Validate(p types.Process, loader types.LoaderWrapper, signers []common.PublicHash) error {
+	sp := p.(*NFT)
+
+	if tx.To == tx.From() {
+		return ErrSelfTransfer
+	}
+	nt, err := sp.Token(loader, tx.CollectionID, tx.TokenID)
+	if err != nil {
+		return err
+	}
+	if nt.Owner != tx.From() {
+		return ErrNotTokenOwner
+	}
+	if has, err := loader.HasAccount(tx.To); err != nil {
+		return err
+	} else if !has {
+		return types.ErrNotExistAccount
+	}
+	if tx.Seq() <= loader.Seq(tx.From()) {
+		return types.ErrInvalidSequence
+	}
+
+	fromAcc, err := loader.Account(tx.From())
+	if err != nil {
+		return err
+	}
+	if err := fromAcc.Validate(loader, signers); err != nil {
+		return err
+	}
+
+	if err := sp.vault.CheckFeePayable(p, loader, tx); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Execute updates the context by the transaction
+func (tx *Transfer) Execute(p types.Process, ctw *types.ContextWrapper, index uint16) error {
+	sp := p.(*NFT)
+
+	return sp.vault.WithFee(p, ctw, tx, func() error {
+		nt, err := sp.Token(ctw, tx.CollectionID, tx.TokenID)
+		if err != nil {
+			return err
+		}
+		if nt.Owner != tx.From() {
+			return ErrNotTokenOwner
+		}
+		nt.Owner = tx.To
+		if err := sp.setToken(ctw, nt); err != nil {
+			return err
+		}
+		return ctw.EmitEvent(&TransferredEvent{
+			Height_:      ctw.TargetHeight(),
+			Index_:       index,
+			CollectionID: tx.CollectionID,
+			TokenID:      tx.TokenID,
+			From:         tx.From(),
+			To:           tx.To,
+		})
+	})
+}
+
+// MarshalJSON is a marshaler function
+func (tx *Transfer) MarshalJSON() ([]byte, error) {
+	var buffer bytes.Buffer
+	buffer.WriteString(`{`)
+	buffer.WriteString(`"timestamp":`)
+	if bs, err := json.Marshal(tx.Timestamp_); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"seq":`)
+	if bs, err := json.Marshal(tx.Seq_); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"from":`)
+	if bs, err := tx.From_.MarshalJSON(); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"collection_id":`)
+	if bs, err := json.Marshal(tx.CollectionID); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"token_id":`)
+	if bs, err := json.Marshal(tx.TokenID); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"to":`)
+	if bs, err := tx.To.MarshalJSON(); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`}`)
+	return buffer.Bytes(), nil
+}
@@ -0,0 +1,78 @@
+package nft
+
+import (
+	"github.com/fletaio/fleta/common/binutil"
+	"github.com/fletaio/fleta/core/types"
+)
+
+// tags
+var (
+	tagCollection      = []byte{1, 0}
+	tagCollectionCount = []byte{1, 1}
+	tagCollectionIndex = []byte{1, 2}
+	tagToken           = []byte{2, 0}
+	tagOwnedCount      = []byte{3, 1}
+	tagOwnedIndex      = []byte{3, 2}
+	tagOwnedPosition   = []byte{3, 3}
+	tagPolicy          = []byte{4, 0}
+)
+
+// toNFTID returns the collection id and the token id as the 14 bytes id
+func toNFTID(CollectionID string, TokenID uint64) ([]byte, error) {
+	Height, Index, err := types.ParseTransactionID(CollectionID)
+	if err != nil {
+		return nil, err
+	}
+	bs := make([]byte, 14)
+	binutil.BigEndian.PutUint32(bs, Height)
+	binutil.BigEndian.PutUint16(bs[4:], Index)
+	binutil.BigEndian.PutUint64(bs[6:], TokenID)
+	return bs, nil
+}
+
+func fromNFTID(bs []byte) (string, uint64) {
+	Height := binutil.BigEndian.Uint32(bs)
+	Index := binutil.BigEndian.Uint16(bs[4:])
+	TokenID := binutil.BigEndian.Uint64(bs[6:])
+	return types.TransactionID(Height, Index), TokenID
+}
+
+func toCollectionKey(CollectionID string) ([]byte, error) {
+	Height, Index, err := types.ParseTransactionID(CollectionID)
+	if err != nil {
+		return nil, err
+	}
+	bs := make([]byte, 8)
+	copy(bs, tagCollection)
+	binutil.BigEndian.PutUint32(bs[2:], Height)
+	binutil.BigEndian.PutUint16(bs[6:], Index)
+	return bs, nil
+}
+
+func toCollectionIndexKey(idx uint32) []byte {
+	bs := make([]byte, 6)
+	copy(bs, tagCollectionIndex)
+	binutil.BigEndian.PutUint32(bs[2:], idx)
+	return bs
+}
+
+func toTokenKey(NFTID []byte) []byte {
+	bs := make([]byte, 2+len(NFTID))
+	copy(bs, tagToken)
+	copy(bs[2:], NFTID)
+	return bs
+}
+
+func toOwnedIndexKey(idx uint32) []byte {
+	bs := make([]byte, 6)
+	copy(bs, tagOwnedIndex)
+	binutil.BigEndian.PutUint32(bs[2:], idx)
+	return bs
+}
+
+func toOwnedPositionKey(NFTID []byte) []byte {
+	bs := make([]byte, 2+len(NFTID))
+	copy(bs, tagOwnedPosition)
+	copy(bs[2:], NFTID)
+	return bs
+}
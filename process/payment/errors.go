@@ -9,9 +9,14 @@ var (
 	ErrInvalidTopicName       = errors.New("invalid topic name")
 	ErrNotExistRequestPayment = errors.New("not exist request payment")
 	ErrExceedContentSize      = errors.New("exceed content size")
+	ErrExceedReferenceIDSize  = errors.New("exceed reference id size")
+	ErrInvalidExpireHeight    = errors.New("invalid expire height")
+	ErrExpiredRequestPayment  = errors.New("expired request payment")
 	ErrExistTopic             = errors.New("exist topic")
 	ErrNotExistTopic          = errors.New("not exist topic")
 	ErrExistSubscribe         = errors.New("exist subscribe")
 	ErrNotExistSubscribe      = errors.New("not exist subscribe")
 	ErrInvalidBillingAmount   = errors.New("invalid billing amount")
+	ErrInvalidBillingPeriod   = errors.New("invalid billing period")
+	ErrNotExistRecurring      = errors.New("not exist recurring")
 )
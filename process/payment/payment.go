@@ -1,9 +1,11 @@
 package payment
 
 import (
+	"github.com/fletaio/fleta/common"
 	"github.com/fletaio/fleta/core/types"
 	"github.com/fletaio/fleta/process/admin"
 	"github.com/fletaio/fleta/process/vault"
+	"github.com/fletaio/fleta/service/apiserver"
 )
 
 // Payment manages balance of accounts of the chain
@@ -66,6 +68,53 @@ func (p *Payment) Init(reg *types.Register, pm types.ProcessManager, cn types.Pr
 	reg.RegisterTransaction(5, &Subscribe{})
 	reg.RegisterTransaction(6, &Unsubscribe{})
 	reg.RegisterTransaction(7, &Billing{})
+	reg.RegisterTransaction(8, &SubscribeRecurring{})
+	reg.RegisterEvent(1, &BillingEvent{})
+
+	if vs, err := pm.ServiceByName("fleta.apiserver"); err != nil {
+		//ignore when not loaded
+	} else if v, is := vs.(*apiserver.APIServer); !is {
+		//ignore when not loaded
+	} else {
+		s, err := v.JRPC("payment")
+		if err != nil {
+			return err
+		}
+		s.Set("requests", func(ID interface{}, arg *apiserver.Argument) (interface{}, error) {
+			if arg.Len() != 1 {
+				return nil, apiserver.ErrInvalidArgument
+			}
+			arg0, err := arg.String(0)
+			if err != nil {
+				return nil, err
+			}
+			addr, err := common.ParseAddress(arg0)
+			if err != nil {
+				return nil, err
+			}
+			loader := cn.NewLoaderWrapper(p.ID())
+			return p.RequestPayments(loader, addr)
+		})
+		s.Set("subscriptions", func(ID interface{}, arg *apiserver.Argument) (interface{}, error) {
+			if arg.Len() != 1 {
+				return nil, apiserver.ErrInvalidArgument
+			}
+			topic, err := arg.Uint64(0)
+			if err != nil {
+				return nil, err
+			}
+			loader := cn.NewLoaderWrapper(p.ID())
+			amountMap, err := p.Subscriptions(loader, topic)
+			if err != nil {
+				return nil, err
+			}
+			subscriptions := map[string]interface{}{}
+			for addr, am := range amountMap {
+				subscriptions[addr.String()] = am
+			}
+			return subscriptions, nil
+		})
+	}
 	return nil
 }
 
@@ -83,17 +132,28 @@ func (p *Payment) InitTopics(ctw *types.ContextWrapper, TopicNames []string) err
 
 // OnLoadChain called when the chain loaded
 func (p *Payment) OnLoadChain(loader types.LoaderWrapper) error {
-	p.admin.AdminAddress(loader, p.Name())
+	if _, err := p.admin.AdminAddress(loader, p.Name()); err != nil {
+		return err
+	}
 	return nil
 }
 
 // BeforeExecuteTransactions called before processes transactions of the block
 func (p *Payment) BeforeExecuteTransactions(ctw *types.ContextWrapper) error {
+	if err := p.backfillIndexes(ctw); err != nil {
+		return err
+	}
 	return nil
 }
 
 // AfterExecuteTransactions called after processes transactions of the block
 func (p *Payment) AfterExecuteTransactions(b *types.Block, ctw *types.ContextWrapper) error {
+	if err := p.processExpiredRequests(ctw); err != nil {
+		return err
+	}
+	if err := p.processBilling(ctw); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -0,0 +1,159 @@
+package payment
+
+import (
+	"github.com/fletaio/fleta/common"
+	"github.com/fletaio/fleta/common/binutil"
+	"github.com/fletaio/fleta/core/types"
+)
+
+func (p *Payment) payerRequestCount(lw types.LoaderWrapper, addr common.Address) uint32 {
+	if bs := lw.AccountData(addr, tagPayerRequestCount); len(bs) > 0 {
+		return binutil.LittleEndian.Uint32(bs)
+	}
+	return 0
+}
+
+func (p *Payment) payerRequestIDs(lw types.LoaderWrapper, addr common.Address) []string {
+	Count := p.payerRequestCount(lw, addr)
+	TXIDs := make([]string, 0, Count)
+	for i := uint32(0); i < Count; i++ {
+		TXIDs = append(TXIDs, string(lw.AccountData(addr, toPayerRequestReverseKey(i))))
+	}
+	return TXIDs
+}
+
+func (p *Payment) addPayerRequest(ctw *types.ContextWrapper, addr common.Address, TXID string) {
+	if ns := ctw.AccountData(addr, toPayerRequestNumberKey(TXID)); len(ns) > 0 {
+		return
+	}
+	Count := p.payerRequestCount(ctw, addr)
+	ctw.SetAccountData(addr, toPayerRequestNumberKey(TXID), binutil.LittleEndian.Uint32ToBytes(Count))
+	ctw.SetAccountData(addr, toPayerRequestReverseKey(Count), []byte(TXID))
+	ctw.SetAccountData(addr, tagPayerRequestCount, binutil.LittleEndian.Uint32ToBytes(Count+1))
+}
+
+func (p *Payment) removePayerRequest(ctw *types.ContextWrapper, addr common.Address, TXID string) {
+	ns := ctw.AccountData(addr, toPayerRequestNumberKey(TXID))
+	if len(ns) == 0 {
+		return
+	}
+	Num := binutil.LittleEndian.Uint32(ns)
+	Last := p.payerRequestCount(ctw, addr) - 1
+	if Num != Last {
+		LastTXID := ctw.AccountData(addr, toPayerRequestReverseKey(Last))
+		ctw.SetAccountData(addr, toPayerRequestNumberKey(string(LastTXID)), binutil.LittleEndian.Uint32ToBytes(Num))
+		ctw.SetAccountData(addr, toPayerRequestReverseKey(Num), LastTXID)
+	}
+	ctw.SetAccountData(addr, toPayerRequestNumberKey(TXID), nil)
+	ctw.SetAccountData(addr, toPayerRequestReverseKey(Last), nil)
+	if Last == 0 {
+		ctw.SetAccountData(addr, tagPayerRequestCount, nil)
+	} else {
+		ctw.SetAccountData(addr, tagPayerRequestCount, binutil.LittleEndian.Uint32ToBytes(Last))
+	}
+}
+
+func (p *Payment) subscriberCount(lw types.LoaderWrapper, topic uint64) uint32 {
+	if bs := lw.ProcessData(toSubscriberCountKey(topic)); len(bs) > 0 {
+		return binutil.LittleEndian.Uint32(bs)
+	}
+	return 0
+}
+
+func (p *Payment) subscribers(lw types.LoaderWrapper, topic uint64) []common.Address {
+	Count := p.subscriberCount(lw, topic)
+	list := make([]common.Address, 0, Count)
+	for i := uint32(0); i < Count; i++ {
+		var addr common.Address
+		copy(addr[:], lw.ProcessData(toSubscriberReverseKey(topic, i)))
+		list = append(list, addr)
+	}
+	return list
+}
+
+func (p *Payment) addSubscriber(ctw *types.ContextWrapper, topic uint64, addr common.Address) {
+	if ns := ctw.ProcessData(toSubscriberNumberKey(topic, addr)); len(ns) > 0 {
+		return
+	}
+	Count := p.subscriberCount(ctw, topic)
+	ctw.SetProcessData(toSubscriberNumberKey(topic, addr), binutil.LittleEndian.Uint32ToBytes(Count))
+	ctw.SetProcessData(toSubscriberReverseKey(topic, Count), addr[:])
+	ctw.SetProcessData(toSubscriberCountKey(topic), binutil.LittleEndian.Uint32ToBytes(Count+1))
+}
+
+func (p *Payment) removeSubscriber(ctw *types.ContextWrapper, topic uint64, addr common.Address) {
+	ns := ctw.ProcessData(toSubscriberNumberKey(topic, addr))
+	if len(ns) == 0 {
+		return
+	}
+	Num := binutil.LittleEndian.Uint32(ns)
+	Last := p.subscriberCount(ctw, topic) - 1
+	if Num != Last {
+		var LastAddr common.Address
+		copy(LastAddr[:], ctw.ProcessData(toSubscriberReverseKey(topic, Last)))
+		ctw.SetProcessData(toSubscriberNumberKey(topic, LastAddr), binutil.LittleEndian.Uint32ToBytes(Num))
+		ctw.SetProcessData(toSubscriberReverseKey(topic, Num), LastAddr[:])
+	}
+	ctw.SetProcessData(toSubscriberNumberKey(topic, addr), nil)
+	ctw.SetProcessData(toSubscriberReverseKey(topic, Last), nil)
+	if Last == 0 {
+		ctw.SetProcessData(toSubscriberCountKey(topic), nil)
+	} else {
+		ctw.SetProcessData(toSubscriberCountKey(topic), binutil.LittleEndian.Uint32ToBytes(Last))
+	}
+}
+
+// IndexBackfillBlocks is the number of stored blocks replayed by a block to backfill the indexes
+const IndexBackfillBlocks = 100
+
+// backfillIndexes adds payment requests and subscriptions made before the indexes to the indexes
+// it replays IndexBackfillBlocks stored blocks per block from the cursor until the first block executed with the indexes
+// the cursor stores the next height to replay and the last height to replay
+func (p *Payment) backfillIndexes(ctw *types.ContextWrapper) error {
+	From := uint32(1)
+	To := ctw.TargetHeight() - 1
+	if bs := ctw.ProcessData(tagIndexBackfill); len(bs) == 8 {
+		From = binutil.LittleEndian.Uint32(bs[:4])
+		To = binutil.LittleEndian.Uint32(bs[4:])
+		if From > To {
+			return nil
+		}
+	}
+	End := From + IndexBackfillBlocks - 1
+	if End > To {
+		End = To
+	}
+	ctw.SetProcessData(tagIndexBackfill, append(binutil.LittleEndian.Uint32ToBytes(End+1), binutil.LittleEndian.Uint32ToBytes(To)...))
+
+	for Height := From; Height <= End; Height++ {
+		b, err := p.cn.Block(Height)
+		if err != nil {
+			return err
+		}
+		for i, t := range b.Transactions {
+			if b.TransactionResults[i] != 1 {
+				continue
+			}
+			switch tx := t.(type) {
+			case *RequestPayment:
+				TXID := types.TransactionID(Height, uint16(i))
+				if _, err := p.getRequestPayment(ctw, TXID); err != nil {
+					if err == ErrNotExistRequestPayment {
+						continue
+					}
+					return err
+				}
+				p.addPayerRequest(ctw, tx.To, TXID)
+			case *Subscribe:
+				if _, err := p.getSubscribe(ctw, tx.Topic, tx.From()); err == nil {
+					p.addSubscriber(ctw, tx.Topic, tx.From())
+				}
+			case *SubscribeRecurring:
+				if _, err := p.getSubscribe(ctw, tx.Topic, tx.From()); err == nil {
+					p.addSubscriber(ctw, tx.Topic, tx.From())
+				}
+			}
+		}
+	}
+	return nil
+}
@@ -25,11 +25,41 @@ func (p *Payment) addRequestPayment(ctw *types.ContextWrapper, TXID string, tx *
 		return err
 	}
 	ctw.SetProcessData(toRequestPaymentKey(TXID), body)
+
+	p.addPayerRequest(ctw, tx.To, TXID)
+	if tx.ExpireHeight > 0 {
+		if err := p.addExpireQueue(ctw, tx.ExpireHeight, TXID); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
-func (p *Payment) removeRequestPayment(ctw *types.ContextWrapper, TXID string) {
+func (p *Payment) removeRequestPayment(ctw *types.ContextWrapper, TXID string) error {
+	req, err := p.getRequestPayment(ctw, TXID)
+	if err != nil {
+		return err
+	}
 	ctw.SetProcessData(toRequestPaymentKey(TXID), nil)
+
+	p.removePayerRequest(ctw, req.To, TXID)
+	return nil
+}
+
+// RequestPayments returns pending payment requests of the payer by the transaction id of the request
+func (p *Payment) RequestPayments(loader types.Loader, addr common.Address) (map[string]*RequestPayment, error) {
+	lw := types.NewLoaderWrapper(p.pid, loader)
+
+	TXIDs := p.payerRequestIDs(lw, addr)
+	reqMap := map[string]*RequestPayment{}
+	for _, TXID := range TXIDs {
+		req, err := p.getRequestPayment(lw, TXID)
+		if err != nil {
+			return nil, err
+		}
+		reqMap[TXID] = req
+	}
+	return reqMap, nil
 }
 
 // GetTopicName returns the topic name of the topic
@@ -69,9 +99,31 @@ func (p *Payment) addSubscribe(ctw *types.ContextWrapper, topic uint64, addr com
 		return ErrExistSubscribe
 	}
 	ctw.SetAccountData(addr, toTopicKey(topic), am.Bytes())
+
+	p.addSubscriber(ctw, topic, addr)
 	return nil
 }
 
 func (p *Payment) removeSubscribe(ctw *types.ContextWrapper, topic uint64, addr common.Address) {
 	ctw.SetAccountData(addr, toTopicKey(topic), nil)
+
+	p.removeSubscriber(ctw, topic, addr)
+}
+
+// Subscriptions returns subscribed amounts of the topic by the subscriber
+func (p *Payment) Subscriptions(loader types.Loader, topic uint64) (map[common.Address]*amount.Amount, error) {
+	lw := types.NewLoaderWrapper(p.pid, loader)
+
+	if _, err := p.GetTopicName(loader, topic); err != nil {
+		return nil, err
+	}
+	amountMap := map[common.Address]*amount.Amount{}
+	for _, addr := range p.subscribers(lw, topic) {
+		am, err := p.getSubscribe(lw, topic, addr)
+		if err != nil {
+			return nil, err
+		}
+		amountMap[addr] = am
+	}
+	return amountMap, nil
 }
@@ -0,0 +1,223 @@
+package payment
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/fletaio/fleta/common"
+	"github.com/fletaio/fleta/common/amount"
+	"github.com/fletaio/fleta/core/types"
+	"github.com/fletaio/fleta/encoding"
+)
+
+// billing constants
+const (
+	MinBillingPeriod     = 1800
+	BillingRetryInterval = 1800
+	MaxBillingGraceCount = 3
+)
+
+// billing statuses of the BillingEvent
+const (
+	BillingCharged   = uint8(1)
+	BillingMissed    = uint8(2)
+	BillingCancelled = uint8(3)
+)
+
+// Recurring is the subscription charged automatically at every period
+type Recurring struct {
+	Topic       uint64
+	Amount      *amount.Amount
+	Period      uint32
+	NextHeight  uint32
+	MissedCount uint8
+}
+
+// MarshalJSON is a marshaler function
+func (rc *Recurring) MarshalJSON() ([]byte, error) {
+	var buffer bytes.Buffer
+	buffer.WriteString(`{`)
+	buffer.WriteString(`"topic":`)
+	if bs, err := json.Marshal(rc.Topic); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"amount":`)
+	if bs, err := rc.Amount.MarshalJSON(); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"period":`)
+	if bs, err := json.Marshal(rc.Period); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"next_height":`)
+	if bs, err := json.Marshal(rc.NextHeight); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"missed_count":`)
+	if bs, err := json.Marshal(rc.MissedCount); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`}`)
+	return buffer.Bytes(), nil
+}
+
+// Recurring returns the recurring subscription of the address
+func (p *Payment) Recurring(loader types.Loader, topic uint64, addr common.Address) (*Recurring, error) {
+	lw := types.NewLoaderWrapper(p.pid, loader)
+
+	bs := lw.AccountData(addr, toRecurringKey(topic))
+	if len(bs) == 0 {
+		return nil, ErrNotExistRecurring
+	}
+	rc := &Recurring{}
+	if err := encoding.Unmarshal(bs, &rc); err != nil {
+		return nil, err
+	}
+	return rc, nil
+}
+
+func (p *Payment) setRecurring(ctw *types.ContextWrapper, addr common.Address, rc *Recurring) error {
+	ctw = types.SwitchContextWrapper(p.pid, ctw)
+
+	bs, err := encoding.Marshal(rc)
+	if err != nil {
+		return err
+	}
+	ctw.SetAccountData(addr, toRecurringKey(rc.Topic), bs)
+	p.addBillingQueue(ctw, rc.NextHeight, rc.Topic, addr)
+	return nil
+}
+
+func (p *Payment) removeRecurring(ctw *types.ContextWrapper, topic uint64, addr common.Address) {
+	ctw = types.SwitchContextWrapper(p.pid, ctw)
+
+	if bs := ctw.AccountData(addr, toRecurringKey(topic)); len(bs) > 0 {
+		ctw.SetAccountData(addr, toRecurringKey(topic), nil)
+	}
+}
+
+func (p *Payment) addBillingQueue(ctw *types.ContextWrapper, Height uint32, topic uint64, addr common.Address) {
+	bs := ctw.ProcessData(toBillingQueueKey(Height))
+	ctw.SetProcessData(toBillingQueueKey(Height), append(bs, toBillingQueueItem(topic, addr)...))
+}
+
+// processBilling charges recurring subscriptions queued at the height of the context
+// a missed charge is retried after BillingRetryInterval and the subscription is cancelled after MaxBillingGraceCount misses
+func (p *Payment) processBilling(ctw *types.ContextWrapper) error {
+	ctw = types.SwitchContextWrapper(p.pid, ctw)
+
+	Height := ctw.TargetHeight()
+	bs := ctw.ProcessData(toBillingQueueKey(Height))
+	if len(bs) == 0 {
+		return nil
+	}
+	ctw.SetProcessData(toBillingQueueKey(Height), nil)
+
+	AdminAddress, err := p.admin.AdminAddress(ctw, p.Name())
+	if err != nil {
+		return err
+	}
+	ItemSize := 8 + common.AddressSize
+	for i := 0; i+ItemSize <= len(bs); i += ItemSize {
+		topic, addr := fromBillingQueueItem(bs[i : i+ItemSize])
+		rc, err := p.Recurring(ctw, topic, addr)
+		if err != nil {
+			if err == ErrNotExistRecurring {
+				continue
+			}
+			return err
+		}
+		if rc.NextHeight != Height {
+			continue
+		}
+		if has, err := ctw.HasAccount(addr); err != nil {
+			if err != types.ErrDeletedAccount {
+				return err
+			}
+			p.removeRecurring(ctw, topic, addr)
+			continue
+		} else if !has {
+			p.removeRecurring(ctw, topic, addr)
+			continue
+		}
+
+		var Status uint8
+		if _, err := p.GetTopicName(ctw, topic); err != nil {
+			if err != ErrNotExistTopic {
+				return err
+			}
+			// the topic is removed so the subscription cannot be charged anymore
+			Status = BillingCancelled
+			p.removeRecurring(ctw, topic, addr)
+			p.removeSubscribe(ctw, topic, addr)
+		} else if p.vault.Balance(ctw, addr).Less(rc.Amount) || p.vault.CheckSpending(ctw, addr, []common.Address{AdminAddress}, rc.Amount) != nil {
+			// a charge that violates the spending policy of the subscriber is missed like an insufficient balance
+			rc.MissedCount++
+			if rc.MissedCount > MaxBillingGraceCount {
+				Status = BillingCancelled
+				p.removeRecurring(ctw, topic, addr)
+				p.removeSubscribe(ctw, topic, addr)
+			} else {
+				Status = BillingMissed
+				Retry := uint32(BillingRetryInterval)
+				if rc.Period < Retry {
+					Retry = rc.Period
+				}
+				rc.NextHeight = Height + Retry
+				if err := p.setRecurring(ctw, addr, rc); err != nil {
+					return err
+				}
+			}
+		} else {
+			if err := p.vault.SubBalance(ctw, addr, rc.Amount); err != nil {
+				return err
+			}
+			if err := p.vault.AddBalance(ctw, AdminAddress, rc.Amount); err != nil {
+				return err
+			}
+			Status = BillingCharged
+			Retried := uint32(0)
+			if rc.MissedCount > 0 {
+				Retry := uint32(BillingRetryInterval)
+				if rc.Period < Retry {
+					Retry = rc.Period
+				}
+				Retried = uint32(rc.MissedCount) * Retry
+			}
+			rc.MissedCount = 0
+			rc.NextHeight = Height - Retried + rc.Period
+			if rc.NextHeight <= Height {
+				rc.NextHeight = Height + 1
+			}
+			if err := p.setRecurring(ctw, addr, rc); err != nil {
+				return err
+			}
+		}
+		if err := ctw.EmitEvent(&BillingEvent{
+			Height_:     Height,
+			Index_:      65535,
+			Topic:       topic,
+			Subscriber:  addr,
+			Amount:      rc.Amount,
+			Status:      Status,
+			MissedCount: rc.MissedCount,
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
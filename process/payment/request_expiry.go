@@ -0,0 +1,46 @@
+package payment
+
+import (
+	"github.com/fletaio/fleta/core/types"
+	"github.com/fletaio/fleta/encoding"
+)
+
+func (p *Payment) addExpireQueue(ctw *types.ContextWrapper, Height uint32, TXID string) error {
+	TXIDs := []string{}
+	if bs := ctw.ProcessData(toExpireQueueKey(Height)); len(bs) > 0 {
+		if err := encoding.Unmarshal(bs, &TXIDs); err != nil {
+			return err
+		}
+	}
+	bs, err := encoding.Marshal(append(TXIDs, TXID))
+	if err != nil {
+		return err
+	}
+	ctw.SetProcessData(toExpireQueueKey(Height), bs)
+	return nil
+}
+
+// processExpiredRequests removes payment requests which are not responded until the height of the context
+func (p *Payment) processExpiredRequests(ctw *types.ContextWrapper) error {
+	ctw = types.SwitchContextWrapper(p.pid, ctw)
+
+	bs := ctw.ProcessData(toExpireQueueKey(ctw.TargetHeight()))
+	if len(bs) == 0 {
+		return nil
+	}
+	ctw.SetProcessData(toExpireQueueKey(ctw.TargetHeight()), nil)
+
+	TXIDs := []string{}
+	if err := encoding.Unmarshal(bs, &TXIDs); err != nil {
+		return err
+	}
+	for _, TXID := range TXIDs {
+		if err := p.removeRequestPayment(ctw, TXID); err != nil {
+			if err == ErrNotExistRequestPayment {
+				continue
+			}
+			return err
+		}
+	}
+	return nil
+}
@@ -37,7 +37,9 @@ func (tx *AddTopic) From() common.Address {
 func (tx *AddTopic) Validate(p types.Process, loader types.LoaderWrapper, signers []common.PublicHash) error {
 	sp := p.(*Payment)
 
-	if tx.From() != sp.admin.AdminAddress(loader, p.Name()) {
+	if AdminAddress, err := sp.admin.AdminAddress(loader, p.Name()); err != nil {
+		return err
+	} else if tx.From() != AdminAddress {
 		return admin.ErrUnauthorizedTransaction
 	}
 	if tx.Topic != Topic(tx.TopicName) {
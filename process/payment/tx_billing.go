@@ -40,7 +40,9 @@ func (tx *Billing) From() common.Address {
 func (tx *Billing) Validate(p types.Process, loader types.LoaderWrapper, signers []common.PublicHash) error {
 	sp := p.(*Payment)
 
-	if tx.From() != sp.admin.AdminAddress(loader, p.Name()) {
+	if AdminAddress, err := sp.admin.AdminAddress(loader, p.Name()); err != nil {
+		return err
+	} else if tx.From() != AdminAddress {
 		return admin.ErrUnauthorizedTransaction
 	}
 	if len(tx.Content) > 255 {
@@ -67,6 +69,9 @@ func (tx *Billing) Validate(p types.Process, loader types.LoaderWrapper, signers
 	if !am.IsZero() && am.Less(tx.Amount) {
 		return ErrInvalidBillingAmount
 	}
+	if err := sp.vault.CheckSpending(loader, tx.To, []common.Address{tx.From()}, tx.Amount); err != nil {
+		return err
+	}
 
 	fromAcc, err := loader.Account(tx.From())
 	if err != nil {
@@ -85,7 +90,11 @@ func (tx *Billing) Execute(p types.Process, ctw *types.ContextWrapper, index uin
 	if err := sp.vault.SubBalance(ctw, tx.To, tx.Amount); err != nil {
 		return err
 	}
-	if err := sp.vault.AddBalance(ctw, sp.admin.AdminAddress(ctw, p.Name()), tx.Amount); err != nil {
+	AdminAddress, err := sp.admin.AdminAddress(ctw, p.Name())
+	if err != nil {
+		return err
+	}
+	if err := sp.vault.AddBalance(ctw, AdminAddress, tx.Amount); err != nil {
 		return err
 	}
 	return nil
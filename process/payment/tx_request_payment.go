@@ -6,19 +6,24 @@ import (
 
 	"github.com/fletaio/fleta/common"
 	"github.com/fletaio/fleta/common/amount"
+	"github.com/fletaio/fleta/common/hash"
 	"github.com/fletaio/fleta/core/types"
 	"github.com/fletaio/fleta/process/admin"
 )
 
 // RequestPayment is a RequestPayment
+// the request is removed at ExpireHeight when it is not responded until then (zero means no expiry)
 type RequestPayment struct {
-	Timestamp_ uint64
-	Seq_       uint64
-	From_      common.Address
-	Topic      uint64
-	To         common.Address
-	Amount     *amount.Amount
-	Content    string
+	Timestamp_    uint64
+	Seq_          uint64
+	From_         common.Address
+	Topic         uint64
+	To            common.Address
+	Amount        *amount.Amount
+	Content       string
+	ExpireHeight  uint32        `msgpack:",omitempty"`
+	ReferenceID   string        `msgpack:",omitempty"`
+	LineItemsHash *hash.Hash256 `msgpack:",omitempty"`
 }
 
 // Timestamp returns the timestamp of the transaction
@@ -40,7 +45,9 @@ func (tx *RequestPayment) From() common.Address {
 func (tx *RequestPayment) Validate(p types.Process, loader types.LoaderWrapper, signers []common.PublicHash) error {
 	sp := p.(*Payment)
 
-	if tx.From() != sp.admin.AdminAddress(loader, p.Name()) {
+	if AdminAddress, err := sp.admin.AdminAddress(loader, p.Name()); err != nil {
+		return err
+	} else if tx.From() != AdminAddress {
 		return admin.ErrUnauthorizedTransaction
 	}
 	if tx.Amount.Less(amount.COIN.DivC(10)) {
@@ -49,6 +56,12 @@ func (tx *RequestPayment) Validate(p types.Process, loader types.LoaderWrapper,
 	if len(tx.Content) > 255 {
 		return ErrExceedContentSize
 	}
+	if len(tx.ReferenceID) > 64 {
+		return ErrExceedReferenceIDSize
+	}
+	if tx.ExpireHeight > 0 && tx.ExpireHeight <= loader.TargetHeight() {
+		return ErrInvalidExpireHeight
+	}
 	if tx.Seq() <= loader.Seq(tx.From()) {
 		return types.ErrInvalidSequence
 	}
@@ -135,6 +148,29 @@ func (tx *RequestPayment) MarshalJSON() ([]byte, error) {
 	} else {
 		buffer.Write(bs)
 	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"expire_height":`)
+	if bs, err := json.Marshal(tx.ExpireHeight); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"reference_id":`)
+	if bs, err := json.Marshal(tx.ReferenceID); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"line_items_hash":`)
+	if tx.LineItemsHash == nil {
+		buffer.WriteString(`null`)
+	} else if bs, err := tx.LineItemsHash.MarshalJSON(); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
 	buffer.WriteString(`}`)
 	return buffer.Bytes(), nil
 }
@@ -47,7 +47,10 @@ func (tx *ResponsePayment) Validate(p types.Process, loader types.LoaderWrapper,
 		return types.ErrInvalidSequence
 	}
 
-	adminAddr := sp.admin.AdminAddress(loader, p.Name())
+	adminAddr, err := sp.admin.AdminAddress(loader, p.Name())
+	if err != nil {
+		return err
+	}
 	req, err := sp.getRequestPayment(loader, tx.TXID)
 	if err != nil {
 		return err
@@ -58,6 +61,9 @@ func (tx *ResponsePayment) Validate(p types.Process, loader types.LoaderWrapper,
 	if req.To != tx.From() {
 		return ErrInvalidRequestPayment
 	}
+	if req.ExpireHeight > 0 && req.ExpireHeight <= loader.TargetHeight() {
+		return ErrExpiredRequestPayment
+	}
 	if !req.Amount.Equal(tx.Amount) {
 		return ErrInvalidPaymentAmount
 	}
@@ -84,6 +90,9 @@ func (tx *ResponsePayment) Validate(p types.Process, loader types.LoaderWrapper,
 		if b.Less(tx.Amount) {
 			return vault.ErrInsufficientBalance
 		}
+		if err := sp.vault.CheckSpending(loader, tx.From(), []common.Address{adminAddr}, tx.Amount); err != nil {
+			return err
+		}
 	}
 	return nil
 }
@@ -96,11 +105,17 @@ func (tx *ResponsePayment) Execute(p types.Process, ctw *types.ContextWrapper, i
 		if err := sp.vault.SubBalance(ctw, tx.From(), tx.Amount); err != nil {
 			return err
 		}
-		if err := sp.vault.AddBalance(ctw, sp.admin.AdminAddress(ctw, p.Name()), tx.Amount); err != nil {
+		AdminAddress, err := sp.admin.AdminAddress(ctw, p.Name())
+		if err != nil {
+			return err
+		}
+		if err := sp.vault.AddBalance(ctw, AdminAddress, tx.Amount); err != nil {
 			return err
 		}
 	}
-	sp.removeRequestPayment(ctw, tx.TXID)
+	if err := sp.removeRequestPayment(ctw, tx.TXID); err != nil {
+		return err
+	}
 	return nil
 }
 
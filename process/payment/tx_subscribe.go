@@ -53,7 +53,10 @@ func (tx *Subscribe) Validate(p types.Process, loader types.LoaderWrapper, signe
 		return admin.ErrUnauthorizedTransaction
 	}
 
-	adminAddr := sp.admin.AdminAddress(loader, p.Name())
+	adminAddr, err := sp.admin.AdminAddress(loader, p.Name())
+	if err != nil {
+		return err
+	}
 	adminAcc, err := loader.Account(adminAddr)
 	if err != nil {
 		return err
@@ -0,0 +1,151 @@
+package payment
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/fletaio/fleta/common"
+	"github.com/fletaio/fleta/common/amount"
+	"github.com/fletaio/fleta/core/types"
+	"github.com/fletaio/fleta/process/admin"
+)
+
+// SubscribeRecurring subscribes the topic with the amount charged automatically at every period
+// the first charge is at the period after the subscription
+type SubscribeRecurring struct {
+	Timestamp_ uint64
+	Seq_       uint64
+	From_      common.Address
+	Topic      uint64
+	Amount     *amount.Amount
+	Period     uint32
+}
+
+// Timestamp returns the timestamp of the transaction
+func (tx *SubscribeRecurring) Timestamp() uint64 {
+	return tx.Timestamp_
+}
+
+// Seq returns the sequence of the transaction
+func (tx *SubscribeRecurring) Seq() uint64 {
+	return tx.Seq_
+}
+
+// From returns the from address of the transaction
+func (tx *SubscribeRecurring) From() common.Address {
+	return tx.From_
+}
+
+// Validate validates signatures of the transaction
+func (tx *SubscribeRecurring) Validate(p types.Process, loader types.LoaderWrapper, signers []common.PublicHash) error {
+	sp := p.(*Payment)
+
+	if tx.Amount.Less(amount.COIN.DivC(10)) {
+		return types.ErrDustAmount
+	}
+	if tx.Period < MinBillingPeriod {
+		return ErrInvalidBillingPeriod
+	}
+	if tx.Seq() <= loader.Seq(tx.From()) {
+		return types.ErrInvalidSequence
+	}
+
+	if _, err := sp.GetTopicName(loader, tx.Topic); err != nil {
+		return err
+	}
+	if _, err := sp.getSubscribe(loader, tx.Topic, tx.From()); err == nil {
+		return ErrExistSubscribe
+	}
+
+	if len(signers) <= 1 {
+		return admin.ErrUnauthorizedTransaction
+	}
+
+	adminAddr, err := sp.admin.AdminAddress(loader, p.Name())
+	if err != nil {
+		return err
+	}
+	adminAcc, err := loader.Account(adminAddr)
+	if err != nil {
+		return err
+	}
+	if err := adminAcc.Validate(loader, signers[:1]); err != nil {
+		return err
+	}
+
+	fromAcc, err := loader.Account(tx.From())
+	if err != nil {
+		return err
+	}
+	if err := fromAcc.Validate(loader, signers[1:]); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Execute updates the context by the transaction
+func (tx *SubscribeRecurring) Execute(p types.Process, ctw *types.ContextWrapper, index uint16) error {
+	sp := p.(*Payment)
+
+	if err := sp.addSubscribe(ctw, tx.Topic, tx.From(), tx.Amount); err != nil {
+		return err
+	}
+	if err := sp.setRecurring(ctw, tx.From(), &Recurring{
+		Topic:      tx.Topic,
+		Amount:     tx.Amount,
+		Period:     tx.Period,
+		NextHeight: ctw.TargetHeight() + tx.Period,
+	}); err != nil {
+		return err
+	}
+	return nil
+}
+
+// MarshalJSON is a marshaler function
+func (tx *SubscribeRecurring) MarshalJSON() ([]byte, error) {
+	var buffer bytes.Buffer
+	buffer.WriteString(`{`)
+	buffer.WriteString(`"timestamp":`)
+	if bs, err := json.Marshal(tx.Timestamp_); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"seq":`)
+	if bs, err := json.Marshal(tx.Seq_); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"from":`)
+	if bs, err := tx.From_.MarshalJSON(); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"topic":`)
+	if bs, err := json.Marshal(tx.Topic); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"amount":`)
+	if bs, err := tx.Amount.MarshalJSON(); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"period":`)
+	if bs, err := json.Marshal(tx.Period); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`}`)
+	return buffer.Bytes(), nil
+}
@@ -44,7 +44,10 @@ func (tx *Unsubscribe) Validate(p types.Process, loader types.LoaderWrapper, sig
 		return admin.ErrUnauthorizedTransaction
 	}
 
-	adminAddr := sp.admin.AdminAddress(loader, p.Name())
+	adminAddr, err := sp.admin.AdminAddress(loader, p.Name())
+	if err != nil {
+		return err
+	}
 	adminAcc, err := loader.Account(adminAddr)
 	if err != nil {
 		return err
@@ -68,6 +71,7 @@ func (tx *Unsubscribe) Execute(p types.Process, ctw *types.ContextWrapper, index
 	sp := p.(*Payment)
 
 	sp.removeSubscribe(ctw, tx.Topic, tx.From())
+	sp.removeRecurring(ctw, tx.Topic, tx.From())
 	return nil
 }
 
@@ -1,13 +1,24 @@
 package payment
 
 import (
+	"github.com/fletaio/fleta/common"
 	"github.com/fletaio/fleta/common/binutil"
 )
 
 // tags
 var (
-	tagRequestPayment = []byte{1, 0}
-	tagTopic          = []byte{2, 0}
+	tagRequestPayment      = []byte{1, 0}
+	tagTopic               = []byte{2, 0}
+	tagRecurring           = []byte{3, 0}
+	tagBillingQueue        = []byte{4, 0}
+	tagPayerRequestCount   = []byte{5, 0}
+	tagPayerRequestNumber  = []byte{5, 1}
+	tagPayerRequestReverse = []byte{5, 2}
+	tagSubscriberCount     = []byte{6, 0}
+	tagSubscriberNumber    = []byte{6, 1}
+	tagSubscriberReverse   = []byte{6, 2}
+	tagExpireQueue         = []byte{7, 0}
+	tagIndexBackfill       = []byte{8, 0}
 )
 
 func toRequestPaymentKey(TXID string) []byte {
@@ -17,9 +28,80 @@ func toRequestPaymentKey(TXID string) []byte {
 	return bs
 }
 
+func toPayerRequestNumberKey(TXID string) []byte {
+	bs := make([]byte, 2+len(TXID))
+	copy(bs, tagPayerRequestNumber)
+	copy(bs[2:], []byte(TXID))
+	return bs
+}
+
+func toPayerRequestReverseKey(Num uint32) []byte {
+	bs := make([]byte, 6)
+	copy(bs, tagPayerRequestReverse)
+	binutil.BigEndian.PutUint32(bs[2:], Num)
+	return bs
+}
+
+func toSubscriberCountKey(topic uint64) []byte {
+	bs := make([]byte, 10)
+	copy(bs, tagSubscriberCount)
+	binutil.BigEndian.PutUint64(bs[2:], topic)
+	return bs
+}
+
+func toSubscriberNumberKey(topic uint64, addr common.Address) []byte {
+	bs := make([]byte, 10+common.AddressSize)
+	copy(bs, tagSubscriberNumber)
+	binutil.BigEndian.PutUint64(bs[2:], topic)
+	copy(bs[10:], addr[:])
+	return bs
+}
+
+func toSubscriberReverseKey(topic uint64, Num uint32) []byte {
+	bs := make([]byte, 14)
+	copy(bs, tagSubscriberReverse)
+	binutil.BigEndian.PutUint64(bs[2:], topic)
+	binutil.BigEndian.PutUint32(bs[10:], Num)
+	return bs
+}
+
+func toExpireQueueKey(Height uint32) []byte {
+	bs := make([]byte, 6)
+	copy(bs, tagExpireQueue)
+	binutil.BigEndian.PutUint32(bs[2:], Height)
+	return bs
+}
+
 func toTopicKey(topic uint64) []byte {
 	bs := make([]byte, 10)
 	copy(bs, tagTopic)
 	binutil.BigEndian.PutUint64(bs[2:], topic)
 	return bs
 }
+
+func toRecurringKey(topic uint64) []byte {
+	bs := make([]byte, 10)
+	copy(bs, tagRecurring)
+	binutil.BigEndian.PutUint64(bs[2:], topic)
+	return bs
+}
+
+func toBillingQueueKey(Height uint32) []byte {
+	bs := make([]byte, 6)
+	copy(bs, tagBillingQueue)
+	binutil.BigEndian.PutUint32(bs[2:], Height)
+	return bs
+}
+
+func toBillingQueueItem(topic uint64, addr common.Address) []byte {
+	bs := make([]byte, 8+common.AddressSize)
+	binutil.BigEndian.PutUint64(bs, topic)
+	copy(bs[8:], addr[:])
+	return bs
+}
+
+func fromBillingQueueItem(bs []byte) (uint64, common.Address) {
+	var addr common.Address
+	copy(addr[:], bs[8:])
+	return binutil.BigEndian.Uint64(bs), addr
+}
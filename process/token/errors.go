@@ -0,0 +1,20 @@
+package token
+
+import "errors"
+
+// errors
+var (
+	ErrInvalidTokenSymbol    = errors.New("invalid token symbol")
+	ErrInvalidTokenName      = errors.New("invalid token name")
+	ErrInvalidDecimals       = errors.New("invalid decimals")
+	ErrExistToken            = errors.New("exist token")
+	ErrNotExistToken         = errors.New("not exist token")
+	ErrMinusInput            = errors.New("minus input")
+	ErrInsufficientBalance   = errors.New("insufficient balance")
+	ErrInsufficientAllowance = errors.New("insufficient allowance")
+	ErrNotMintAuthority      = errors.New("not mint authority")
+	ErrMintDisabled          = errors.New("mint disabled")
+	ErrSelfApprove           = errors.New("self approve")
+	ErrInvalidTokenAmount    = errors.New("invalid token amount")
+	ErrInvalidPolicy         = errors.New("invalid policy")
+)
@@ -0,0 +1,73 @@
+package token
+
+import (
+	"bytes"
+
+	"github.com/fletaio/fleta/common/amount"
+	"github.com/fletaio/fleta/core/types"
+	"github.com/fletaio/fleta/encoding"
+)
+
+// Policy defines the cost of creating the token
+type Policy struct {
+	CreationCost *amount.Amount
+}
+
+// DefaultPolicy returns the policy used when the policy is not initialized at the genesis
+func DefaultPolicy() *Policy {
+	return &Policy{
+		CreationCost: amount.NewCoinAmount(10, 0), // 10 FLETA
+	}
+}
+
+// Validate checks the policy
+func (pc *Policy) Validate() error {
+	if pc.CreationCost == nil || pc.CreationCost.Less(amount.NewCoinAmount(0, 0)) {
+		return ErrInvalidPolicy
+	}
+	return nil
+}
+
+// MarshalJSON is a marshaler function
+func (pc *Policy) MarshalJSON() ([]byte, error) {
+	var buffer bytes.Buffer
+	buffer.WriteString(`{`)
+	buffer.WriteString(`"creation_cost":`)
+	if bs, err := pc.CreationCost.MarshalJSON(); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`}`)
+	return buffer.Bytes(), nil
+}
+
+// InitPolicy called at OnInitGenesis of an application
+func (p *Token) InitPolicy(ctw *types.ContextWrapper, policy *Policy) error {
+	ctw = types.SwitchContextWrapper(p.pid, ctw)
+
+	if err := policy.Validate(); err != nil {
+		return err
+	}
+	bs, err := encoding.Marshal(policy)
+	if err != nil {
+		return err
+	}
+	ctw.SetProcessData(tagPolicy, bs)
+	return nil
+}
+
+// GetPolicy returns the policy of the process
+func (p *Token) GetPolicy(loader types.Loader) (*Policy, error) {
+	lw := types.NewLoaderWrapper(p.pid, loader)
+
+	bs := lw.ProcessData(tagPolicy)
+	if len(bs) == 0 {
+		return DefaultPolicy(), nil
+	}
+	policy := &Policy{}
+	if err := encoding.Unmarshal(bs, &policy); err != nil {
+		return nil, err
+	}
+	return policy, nil
+}
@@ -0,0 +1,189 @@
+package token
+
+import (
+	"github.com/fletaio/fleta/common"
+	"github.com/fletaio/fleta/core/types"
+	"github.com/fletaio/fleta/process/vault"
+	"github.com/fletaio/fleta/service/apiserver"
+)
+
+// Token manages user created tokens and their balances
+type Token struct {
+	*types.ProcessBase
+	pid   uint8
+	pm    types.ProcessManager
+	cn    types.Provider
+	vault *vault.Vault
+}
+
+// NewToken returns a Token
+func NewToken(pid uint8) *Token {
+	p := &Token{
+		pid: pid,
+	}
+	return p
+}
+
+// ID returns the id of the process
+func (p *Token) ID() uint8 {
+	return p.pid
+}
+
+// Name returns the name of the process
+func (p *Token) Name() string {
+	return "fleta.token"
+}
+
+// Version returns the version of the process
+func (p *Token) Version() string {
+	return "0.0.1"
+}
+
+// Init initializes the process
+func (p *Token) Init(reg *types.Register, pm types.ProcessManager, cn types.Provider) error {
+	p.pm = pm
+	p.cn = cn
+
+	if vp, err := pm.ProcessByName("fleta.vault"); err != nil {
+		return err
+	} else if v, is := vp.(*vault.Vault); !is {
+		return types.ErrInvalidProcess
+	} else {
+		p.vault = v
+	}
+
+	reg.RegisterTransaction(1, &CreateToken{})
+	reg.RegisterTransaction(2, &Transfer{})
+	reg.RegisterTransaction(3, &Approve{})
+	reg.RegisterTransaction(4, &TransferFrom{})
+	reg.RegisterTransaction(5, &Mint{})
+	reg.RegisterTransaction(6, &Burn{})
+
+	if vs, err := pm.ServiceByName("fleta.apiserver"); err != nil {
+		//ignore when not loaded
+	} else if v, is := vs.(*apiserver.APIServer); !is {
+		//ignore when not loaded
+	} else {
+		s, err := v.JRPC("token")
+		if err != nil {
+			return err
+		}
+		s.Set("policy", func(ID interface{}, arg *apiserver.Argument) (interface{}, error) {
+			loader := cn.NewLoaderWrapper(p.ID())
+			return p.GetPolicy(loader)
+		})
+		s.Set("tokenInfo", func(ID interface{}, arg *apiserver.Argument) (interface{}, error) {
+			if arg.Len() != 1 {
+				return nil, apiserver.ErrInvalidArgument
+			}
+			Symbol, err := arg.String(0)
+			if err != nil {
+				return nil, err
+			}
+			loader := cn.NewLoaderWrapper(p.ID())
+			return p.TokenInfo(loader, Symbol)
+		})
+		s.Set("tokens", func(ID interface{}, arg *apiserver.Argument) (interface{}, error) {
+			loader := cn.NewLoaderWrapper(p.ID())
+			return p.Tokens(loader)
+		})
+		s.Set("balance", func(ID interface{}, arg *apiserver.Argument) (interface{}, error) {
+			if arg.Len() != 2 {
+				return nil, apiserver.ErrInvalidArgument
+			}
+			arg0, err := arg.String(0)
+			if err != nil {
+				return nil, err
+			}
+			addr, err := common.ParseAddress(arg0)
+			if err != nil {
+				return nil, err
+			}
+			Symbol, err := arg.String(1)
+			if err != nil {
+				return nil, err
+			}
+			loader := cn.NewLoaderWrapper(p.ID())
+			if _, err := p.TokenInfo(loader, Symbol); err != nil {
+				return nil, err
+			}
+			return p.Balance(loader, addr, Symbol), nil
+		})
+		s.Set("balances", func(ID interface{}, arg *apiserver.Argument) (interface{}, error) {
+			if arg.Len() != 1 {
+				return nil, apiserver.ErrInvalidArgument
+			}
+			arg0, err := arg.String(0)
+			if err != nil {
+				return nil, err
+			}
+			addr, err := common.ParseAddress(arg0)
+			if err != nil {
+				return nil, err
+			}
+			loader := cn.NewLoaderWrapper(p.ID())
+			tokens, err := p.Tokens(loader)
+			if err != nil {
+				return nil, err
+			}
+			balances := map[string]interface{}{}
+			for _, ti := range tokens {
+				if am := p.Balance(loader, addr, ti.Symbol); !am.IsZero() {
+					balances[ti.Symbol] = am
+				}
+			}
+			return balances, nil
+		})
+		s.Set("allowance", func(ID interface{}, arg *apiserver.Argument) (interface{}, error) {
+			if arg.Len() != 3 {
+				return nil, apiserver.ErrInvalidArgument
+			}
+			arg0, err := arg.String(0)
+			if err != nil {
+				return nil, err
+			}
+			Owner, err := common.ParseAddress(arg0)
+			if err != nil {
+				return nil, err
+			}
+			arg1, err := arg.String(1)
+			if err != nil {
+				return nil, err
+			}
+			Spender, err := common.ParseAddress(arg1)
+			if err != nil {
+				return nil, err
+			}
+			Symbol, err := arg.String(2)
+			if err != nil {
+				return nil, err
+			}
+			loader := cn.NewLoaderWrapper(p.ID())
+			if _, err := p.TokenInfo(loader, Symbol); err != nil {
+				return nil, err
+			}
+			return p.Allowance(loader, Owner, Spender, Symbol), nil
+		})
+	}
+	return nil
+}
+
+// OnLoadChain called when the chain loaded
+func (p *Token) OnLoadChain(loader types.LoaderWrapper) error {
+	return nil
+}
+
+// BeforeExecuteTransactions called before processes transactions of the block
+func (p *Token) BeforeExecuteTransactions(ctw *types.ContextWrapper) error {
+	return nil
+}
+
+// AfterExecuteTransactions called after processes transactions of the block
+func (p *Token) AfterExecuteTransactions(b *types.Block, ctw *types.ContextWrapper) error {
+	return nil
+}
+
+// OnSaveData called when the context of the block saved
+func (p *Token) OnSaveData(b *types.Block, ctw *types.ContextWrapper) error {
+	return nil
+}
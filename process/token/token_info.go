@@ -0,0 +1,74 @@
+package token
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/fletaio/fleta/common"
+	"github.com/fletaio/fleta/common/amount"
+)
+
+// TokenInfo is the information of the token
+// zero MintAuthority means that the supply is fixed
+type TokenInfo struct {
+	Symbol        string
+	Name          string
+	Decimals      uint8
+	Creator       common.Address
+	MintAuthority common.Address
+	TotalSupply   *amount.Amount
+}
+
+// MarshalJSON is a marshaler function
+func (ti *TokenInfo) MarshalJSON() ([]byte, error) {
+	var buffer bytes.Buffer
+	buffer.WriteString(`{`)
+	buffer.WriteString(`"symbol":`)
+	if bs, err := json.Marshal(ti.Symbol); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"name":`)
+	if bs, err := json.Marshal(ti.Name); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"decimals":`)
+	if bs, err := json.Marshal(ti.Decimals); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"creator":`)
+	if bs, err := ti.Creator.MarshalJSON(); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"mint_authority":`)
+	if bs, err := ti.MintAuthority.MarshalJSON(); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"total_supply":`)
+	if bs, err := ti.TotalSupply.MarshalJSON(); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`}`)
+	return buffer.Bytes(), nil
+}
+
+// IsMintable returns true when the token has the mint authority
+func (ti *TokenInfo) IsMintable() bool {
+	return ti.MintAuthority != common.Address{}
+}
@@ -0,0 +1,150 @@
+package token
+
+import (
+	"github.com/fletaio/fleta/common"
+	"github.com/fletaio/fleta/common/amount"
+	"github.com/fletaio/fleta/common/binutil"
+	"github.com/fletaio/fleta/core/types"
+	"github.com/fletaio/fleta/encoding"
+)
+
+// ValidateSymbol checks that the symbol consists of 2 to 12 uppercase letters or digits
+func ValidateSymbol(Symbol string) error {
+	if len(Symbol) < 2 || len(Symbol) > 12 {
+		return ErrInvalidTokenSymbol
+	}
+	for _, c := range Symbol {
+		if (c < 'A' || c > 'Z') && (c < '0' || c > '9') {
+			return ErrInvalidTokenSymbol
+		}
+	}
+	if Symbol == "FLETA" {
+		return ErrInvalidTokenSymbol
+	}
+	return nil
+}
+
+// TokenInfo returns the information of the token
+func (p *Token) TokenInfo(loader types.Loader, Symbol string) (*TokenInfo, error) {
+	lw := types.NewLoaderWrapper(p.pid, loader)
+
+	bs := lw.ProcessData(toTokenKey(Symbol))
+	if len(bs) == 0 {
+		return nil, ErrNotExistToken
+	}
+	ti := &TokenInfo{}
+	if err := encoding.Unmarshal(bs, &ti); err != nil {
+		return nil, err
+	}
+	return ti, nil
+}
+
+func (p *Token) setTokenInfo(ctw *types.ContextWrapper, ti *TokenInfo) error {
+	ctw = types.SwitchContextWrapper(p.pid, ctw)
+
+	bs, err := encoding.Marshal(ti)
+	if err != nil {
+		return err
+	}
+	ctw.SetProcessData(toTokenKey(ti.Symbol), bs)
+	return nil
+}
+
+// TokenCount returns the number of created tokens
+func (p *Token) TokenCount(loader types.Loader) uint32 {
+	lw := types.NewLoaderWrapper(p.pid, loader)
+
+	if bs := lw.ProcessData(tagTokenCount); len(bs) > 0 {
+		return binutil.BigEndian.Uint32(bs)
+	}
+	return 0
+}
+
+// Tokens returns informations of created tokens in the creation order
+func (p *Token) Tokens(loader types.Loader) ([]*TokenInfo, error) {
+	lw := types.NewLoaderWrapper(p.pid, loader)
+
+	Count := p.TokenCount(lw)
+	list := make([]*TokenInfo, 0, Count)
+	for i := uint32(0); i < Count; i++ {
+		ti, err := p.TokenInfo(lw, string(lw.ProcessData(toTokenIndexKey(i))))
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, ti)
+	}
+	return list, nil
+}
+
+func (p *Token) addToken(ctw *types.ContextWrapper, ti *TokenInfo) error {
+	ctw = types.SwitchContextWrapper(p.pid, ctw)
+
+	if bs := ctw.ProcessData(toTokenKey(ti.Symbol)); len(bs) > 0 {
+		return ErrExistToken
+	}
+	if err := p.setTokenInfo(ctw, ti); err != nil {
+		return err
+	}
+	Count := p.TokenCount(ctw)
+	ctw.SetProcessData(toTokenIndexKey(Count), []byte(ti.Symbol))
+	ctw.SetProcessData(tagTokenCount, binutil.BigEndian.Uint32ToBytes(Count+1))
+	return nil
+}
+
+// Balance returns the token balance of the account of the address
+func (p *Token) Balance(loader types.Loader, addr common.Address, Symbol string) *amount.Amount {
+	lw := types.NewLoaderWrapper(p.pid, loader)
+
+	if bs := lw.AccountData(addr, toBalanceKey(Symbol)); len(bs) > 0 {
+		return amount.NewAmountFromBytes(bs)
+	}
+	return amount.NewCoinAmount(0, 0)
+}
+
+// AddBalance adds the token balance to the account of the address
+func (p *Token) AddBalance(ctw *types.ContextWrapper, addr common.Address, Symbol string, am *amount.Amount) error {
+	ctw = types.SwitchContextWrapper(p.pid, ctw)
+
+	if am.Less(amount.NewCoinAmount(0, 0)) {
+		return ErrMinusInput
+	}
+	ctw.SetAccountData(addr, toBalanceKey(Symbol), p.Balance(ctw, addr, Symbol).Add(am).Bytes())
+	return nil
+}
+
+// SubBalance subtracts the token balance from the account of the address
+func (p *Token) SubBalance(ctw *types.ContextWrapper, addr common.Address, Symbol string, am *amount.Amount) error {
+	ctw = types.SwitchContextWrapper(p.pid, ctw)
+
+	sum := p.Balance(ctw, addr, Symbol)
+	if sum.Less(am) {
+		return ErrInsufficientBalance
+	}
+	sum = sum.Sub(am)
+	if sum.IsZero() {
+		ctw.SetAccountData(addr, toBalanceKey(Symbol), nil)
+	} else {
+		ctw.SetAccountData(addr, toBalanceKey(Symbol), sum.Bytes())
+	}
+	return nil
+}
+
+// Allowance returns the amount that the spender can transfer from the owner
+func (p *Token) Allowance(loader types.Loader, Owner common.Address, Spender common.Address, Symbol string) *amount.Amount {
+	lw := types.NewLoaderWrapper(p.pid, loader)
+
+	if bs := lw.AccountData(Owner, toAllowanceKey(Spender, Symbol)); len(bs) > 0 {
+		return amount.NewAmountFromBytes(bs)
+	}
+	return amount.NewCoinAmount(0, 0)
+}
+
+func (p *Token) setAllowance(ctw *types.ContextWrapper, Owner common.Address, Spender common.Address, Symbol string, am *amount.Amount) {
+	ctw = types.SwitchContextWrapper(p.pid, ctw)
+
+	if am.IsZero() {
+		ctw.SetAccountData(Owner, toAllowanceKey(Spender, Symbol), nil)
+	} else {
+		ctw.SetAccountData(Owner, toAllowanceKey(Spender, Symbol), am.Bytes())
+	}
+}
@@ -0,0 +1,130 @@
+package token
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/fletaio/fleta/common"
+	"github.com/fletaio/fleta/common/amount"
+	"github.com/fletaio/fleta/core/types"
+)
+
+// Burn removes the token of the sender from the supply
+type Burn struct {
+	Timestamp_ uint64
+	Seq_       uint64
+	From_      common.Address
+	Symbol     string
+	Amount     *amount.Amount
+}
+
+// Timestamp returns the timestamp of the transaction
+func (tx *Burn) Timestamp() uint64 {
+	return tx.Timestamp_
+}
+
+// Seq returns the sequence of the transaction
+func (tx *Burn) Seq() uint64 {
+	return tx.Seq_
+}
+
+// From returns the from address of the transaction
+func (tx *Burn) From() common.Address {
+	return tx.From_
+}
+
+// Fee returns the fee of the transaction
+func (tx *Burn) Fee(p types.Process, loader types.LoaderWrapper) *amount.Amount {
+	sp := p.(*Token)
+	return sp.vault.GetDefaultFee(loader)
+}
+
+// Validate validates signatures of the transaction
+func (tx *Burn) Validate(p types.Process, loader types.LoaderWrapper, signers []common.PublicHash) error {
+	sp := p.(*Token)
+
+	if tx.Amount.IsZero() || tx.Amount.Less(amount.NewCoinAmount(0, 0)) {
+		return ErrInvalidTokenAmount
+	}
+	if _, err := sp.TokenInfo(loader, tx.Symbol); err != nil {
+		return err
+	}
+	if sp.Balance(loader, tx.From(), tx.Symbol).Less(tx.Amount) {
+		return ErrInsufficientBalance
+	}
+	if tx.Seq() <= loader.Seq(tx.From()) {
+		return types.ErrInvalidSequence
+	}
+
+	fromAcc, err := loader.Account(tx.From())
+	if err != nil {
+		return err
+	}
+	if err := fromAcc.Validate(loader, signers); err != nil {
+		return err
+	}
+
+	if err := sp.vault.CheckFeePayable(p, loader, tx); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Execute updates the context by the transaction
+func (tx *Burn) Execute(p types.Process, ctw *types.ContextWrapper, index uint16) error {
+	sp := p.(*Token)
+
+	return sp.vault.WithFee(p, ctw, tx, func() error {
+		if err := sp.SubBalance(ctw, tx.From(), tx.Symbol, tx.Amount); err != nil {
+			return err
+		}
+		ti, err := sp.TokenInfo(ctw, tx.Symbol)
+		if err != nil {
+			return err
+		}
+		ti.TotalSupply = ti.TotalSupply.Sub(tx.Amount)
+		return sp.setTokenInfo(ctw, ti)
+	})
+}
+
+// MarshalJSON is a marshaler function
+func (tx *Burn) MarshalJSON() ([]byte, error) {
+	var buffer bytes.Buffer
+	buffer.WriteString(`{`)
+	buffer.WriteString(`"timestamp":`)
+	if bs, err := json.Marshal(tx.Timestamp_); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"seq":`)
+	if bs, err := json.Marshal(tx.Seq_); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"from":`)
+	if bs, err := tx.From_.MarshalJSON(); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"symbol":`)
+	if bs, err := json.Marshal(tx.Symbol); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"amount":`)
+	if bs, err := tx.Amount.MarshalJSON(); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`}`)
+	return buffer.Bytes(), nil
+}
@@ -0,0 +1,195 @@
+package token
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/fletaio/fleta/common"
+	"github.com/fletaio/fleta/common/amount"
+	"github.com/fletaio/fleta/core/types"
+)
+
+// CreateToken creates the token and gives the initial supply to the creator
+// zero MintAuthority makes the supply fixed
+type CreateToken struct {
+	Timestamp_    uint64
+	Seq_          uint64
+	From_         common.Address
+	Symbol        string
+	Name          string
+	Decimals      uint8
+	InitialSupply *amount.Amount
+	MintAuthority common.Address
+}
+
+// Timestamp returns the timestamp of the transaction
+func (tx *CreateToken) Timestamp() uint64 {
+	return tx.Timestamp_
+}
+
+// Seq returns the sequence of the transaction
+func (tx *CreateToken) Seq() uint64 {
+	return tx.Seq_
+}
+
+// From returns the from address of the transaction
+func (tx *CreateToken) From() common.Address {
+	return tx.From_
+}
+
+// Fee returns the fee of the transaction
+func (tx *CreateToken) Fee(p types.Process, loader types.LoaderWrapper) *amount.Amount {
+	sp := p.(*Token)
+	return sp.vault.GetDefaultFee(loader)
+}
+
+// Validate validates signatures of the transaction
+func (tx *CreateToken) Validate(p types.Process, loader types.LoaderWrapper, signers []common.PublicHash) error {
+	sp := p.(*Token)
+
+	if err := ValidateSymbol(tx.Symbol); err != nil {
+		return err
+	}
+	if len(tx.Name) < 1 || len(tx.Name) > 64 {
+		return ErrInvalidTokenName
+	}
+	if tx.Decimals > 18 {
+		return ErrInvalidDecimals
+	}
+	if tx.InitialSupply.Less(amount.NewCoinAmount(0, 0)) {
+		return ErrInvalidTokenAmount
+	}
+	if tx.InitialSupply.IsZero() && tx.MintAuthority == (common.Address{}) {
+		return ErrInvalidTokenAmount
+	}
+	if _, err := sp.TokenInfo(loader, tx.Symbol); err == nil {
+		return ErrExistToken
+	} else if err != ErrNotExistToken {
+		return err
+	}
+	if tx.MintAuthority != (common.Address{}) {
+		if has, err := loader.HasAccount(tx.MintAuthority); err != nil {
+			return err
+		} else if !has {
+			return types.ErrNotExistAccount
+		}
+	}
+	if tx.Seq() <= loader.Seq(tx.From()) {
+		return types.ErrInvalidSequence
+	}
+
+	fromAcc, err := loader.Account(tx.From())
+	if err != nil {
+		return err
+	}
+	if err := fromAcc.Validate(loader, signers); err != nil {
+		return err
+	}
+
+	policy, err := sp.GetPolicy(loader)
+	if err != nil {
+		return err
+	}
+	if err := sp.vault.CheckFeePayableWith(p, loader, tx, policy.CreationCost); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Execute updates the context by the transaction
+func (tx *CreateToken) Execute(p types.Process, ctw *types.ContextWrapper, index uint16) error {
+	sp := p.(*Token)
+
+	return sp.vault.WithFee(p, ctw, tx, func() error {
+		policy, err := sp.GetPolicy(ctw)
+		if err != nil {
+			return err
+		}
+		if err := sp.vault.SubBalance(ctw, tx.From(), policy.CreationCost); err != nil {
+			return err
+		}
+		if err := sp.vault.AddCollectedFee(ctw, policy.CreationCost); err != nil {
+			return err
+		}
+
+		if err := sp.addToken(ctw, &TokenInfo{
+			Symbol:        tx.Symbol,
+			Name:          tx.Name,
+			Decimals:      tx.Decimals,
+			Creator:       tx.From(),
+			MintAuthority: tx.MintAuthority,
+			TotalSupply:   tx.InitialSupply,
+		}); err != nil {
+			return err
+		}
+		if !tx.InitialSupply.IsZero() {
+			if err := sp.AddBalance(ctw, tx.From(), tx.Symbol, tx.InitialSupply); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// MarshalJSON is a marshaler function
+func (tx *CreateToken) MarshalJSON() ([]byte, error) {
+	var buffer bytes.Buffer
+	buffer.WriteString(`{`)
+	buffer.WriteString(`"timestamp":`)
+	if bs, err := json.Marshal(tx.Timestamp_); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"seq":`)
+	if bs, err := json.Marshal(tx.Seq_); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"from":`)
+	if bs, err := tx.From_.MarshalJSON(); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"symbol":`)
+	if bs, err := json.Marshal(tx.Symbol); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"name":`)
+	if bs, err := json.Marshal(tx.Name); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"decimals":`)
+	if bs, err := json.Marshal(tx.Decimals); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"initial_supply":`)
+	if bs, err := tx.InitialSupply.MarshalJSON(); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"mint_authority":`)
+	if bs, err := tx.MintAuthority.MarshalJSON(); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`}`)
+	return buffer.Bytes(), nil
+}
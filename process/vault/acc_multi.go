@@ -42,12 +42,16 @@ func (acc *MultiAccount) Clone() types.Account {
 }
 
 // Validate validates account signers
+// it requires at least Required distinct signers that are listed in the key hashes
 func (acc *MultiAccount) Validate(loader types.LoaderWrapper, signers []common.PublicHash) error {
-	if len(acc.KeyHashes) != len(signers) {
+	if len(signers) < int(acc.Required) {
 		return types.ErrInvalidSignerCount
 	}
 	signerMap := map[common.PublicHash]bool{}
 	for _, signer := range signers {
+		if signerMap[signer] {
+			return types.ErrInvalidAccountSigner
+		}
 		signerMap[signer] = true
 	}
 	matchCount := 0
@@ -56,12 +60,36 @@ func (acc *MultiAccount) Validate(loader types.LoaderWrapper, signers []common.P
 			matchCount++
 		}
 	}
-	if matchCount != int(acc.Required) {
+	if matchCount < int(acc.Required) {
 		return types.ErrInvalidAccountSigner
 	}
 	return nil
 }
 
+// IsKeyHash returns true when the public hash is one of the key hashes
+func (acc *MultiAccount) IsKeyHash(pubhash common.PublicHash) bool {
+	for _, v := range acc.KeyHashes {
+		if v == pubhash {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidateRecoveryKeys checks keys of the recovery can be applied to the account
+func (acc *MultiAccount) ValidateRecoveryKeys(Required uint8, KeyHashes []common.PublicHash) error {
+	if len(KeyHashes) <= 1 {
+		return ErrInvalidMultiKeyHashCount
+	}
+	return ValidateKeyHashes(Required, KeyHashes, 10)
+}
+
+// ApplyRecoveryKeys replaces keys of the account by the recovery
+func (acc *MultiAccount) ApplyRecoveryKeys(Required uint8, KeyHashes []common.PublicHash) {
+	acc.Required = Required
+	acc.KeyHashes = KeyHashes
+}
+
 // MarshalJSON is a marshaler function
 func (acc *MultiAccount) MarshalJSON() ([]byte, error) {
 	var buffer bytes.Buffer
@@ -46,6 +46,19 @@ func (acc *SingleAccount) Validate(loader types.LoaderWrapper, signers []common.
 	return nil
 }
 
+// ValidateRecoveryKeys checks keys of the recovery can be applied to the account
+func (acc *SingleAccount) ValidateRecoveryKeys(Required uint8, KeyHashes []common.PublicHash) error {
+	if Required != 1 || len(KeyHashes) != 1 {
+		return ErrInvalidRequiredKeyHashCount
+	}
+	return nil
+}
+
+// ApplyRecoveryKeys replaces keys of the account by the recovery
+func (acc *SingleAccount) ApplyRecoveryKeys(Required uint8, KeyHashes []common.PublicHash) {
+	acc.KeyHash = KeyHashes[0]
+}
+
 // MarshalJSON is a marshaler function
 func (acc *SingleAccount) MarshalJSON() ([]byte, error) {
 	var buffer bytes.Buffer
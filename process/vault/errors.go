@@ -17,4 +17,29 @@ var (
 	ErrPolicyShouldBeSetupInApplication = errors.New("policy should be setup in application")
 	ErrInvalidTagSize                   = errors.New("invalid tag size")
 	ErrInvalidDefaultFee                = errors.New("invalid default fee")
+	ErrInvalidUnlockHeight              = errors.New("invalid unlock height")
+	ErrInvalidVestingCount              = errors.New("invalid vesting count")
+	ErrInvalidVestingInterval           = errors.New("invalid vesting interval")
+	ErrInvalidOutputCount               = errors.New("invalid output count")
+	ErrNotRecoverableAccount            = errors.New("not recoverable account")
+	ErrNotExistRecoveryGuardian         = errors.New("not exist recovery guardian")
+	ErrNotExistPendingRecovery          = errors.New("not exist pending recovery")
+	ErrExistPendingRecovery             = errors.New("exist pending recovery")
+	ErrInvalidRecoveryDelay             = errors.New("invalid recovery delay")
+	ErrRecoveryDelayNotPassed           = errors.New("recovery delay not passed")
+	ErrNotExistSpendingPolicy           = errors.New("not exist spending policy")
+	ErrInvalidSpendingPolicy            = errors.New("invalid spending policy")
+	ErrExceedSpendingLimit              = errors.New("exceed spending limit")
+	ErrNotAllowedDestination            = errors.New("not allowed destination")
+	ErrNotExistHTLC                     = errors.New("not exist htlc")
+	ErrInvalidExpireHeight              = errors.New("invalid expire height")
+	ErrExpiredHTLC                      = errors.New("expired htlc")
+	ErrNotExpiredHTLC                   = errors.New("not expired htlc")
+	ErrInvalidPreimage                  = errors.New("invalid preimage")
+	ErrNotHTLCRecipient                 = errors.New("not htlc recipient")
+	ErrNotHTLCSender                    = errors.New("not htlc sender")
+	ErrInvalidSponsoredTransaction      = errors.New("invalid sponsored transaction")
+	ErrSelfSponsored                    = errors.New("self sponsored")
+	ErrInvalidDynamicFeePolicy          = errors.New("invalid dynamic fee policy")
+	ErrNotExistDynamicFeePolicy         = errors.New("not exist dynamic fee policy")
 )
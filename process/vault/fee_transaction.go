@@ -10,3 +10,19 @@ type FeeTransaction interface {
 	From() common.Address
 	Fee(p types.Process, lw types.LoaderWrapper) *amount.Amount
 }
+
+// FeePayer returns the address that pays the fee of the transaction
+func (p *Vault) FeePayer(tx FeeTransaction) common.Address {
+	if v, has := p.sponsorMap.Load(tx); has {
+		return v.(common.Address)
+	}
+	return tx.From()
+}
+
+// withSponsor runs the function while the fee of the inner transaction is paid by the payer
+func (p *Vault) withSponsor(tx FeeTransaction, Payer common.Address, fn func() error) error {
+	p.sponsorMap.Store(tx, Payer)
+	defer p.sponsorMap.Delete(tx)
+
+	return fn()
+}
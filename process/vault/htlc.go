@@ -0,0 +1,102 @@
+package vault
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/fletaio/fleta/common"
+	"github.com/fletaio/fleta/common/amount"
+	"github.com/fletaio/fleta/common/hash"
+	"github.com/fletaio/fleta/core/types"
+	"github.com/fletaio/fleta/encoding"
+)
+
+// HTLC is a hashed time-locked contract
+// the amount is sent to To with the preimage of HashLock before ExpireHeight or refunded to From after it
+type HTLC struct {
+	From         common.Address
+	To           common.Address
+	Amount       *amount.Amount
+	HashLock     hash.Hash256
+	ExpireHeight uint32
+}
+
+// MarshalJSON is a marshaler function
+func (ht *HTLC) MarshalJSON() ([]byte, error) {
+	var buffer bytes.Buffer
+	buffer.WriteString(`{`)
+	buffer.WriteString(`"from":`)
+	if bs, err := ht.From.MarshalJSON(); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"to":`)
+	if bs, err := ht.To.MarshalJSON(); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"amount":`)
+	if bs, err := ht.Amount.MarshalJSON(); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"hash_lock":`)
+	if bs, err := ht.HashLock.MarshalJSON(); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"expire_height":`)
+	if bs, err := json.Marshal(ht.ExpireHeight); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`}`)
+	return buffer.Bytes(), nil
+}
+
+// HTLC returns the hashed time-locked contract of the contract id
+func (p *Vault) HTLC(loader types.Loader, ContractID string) (*HTLC, error) {
+	lw := types.NewLoaderWrapper(p.pid, loader)
+
+	Height, Index, err := types.ParseTransactionID(ContractID)
+	if err != nil {
+		return nil, err
+	}
+	bs := lw.ProcessData(toHTLCKey(Height, Index))
+	if len(bs) == 0 {
+		return nil, ErrNotExistHTLC
+	}
+	ht := &HTLC{}
+	if err := encoding.Unmarshal(bs, &ht); err != nil {
+		return nil, err
+	}
+	return ht, nil
+}
+
+func (p *Vault) setHTLC(ctw *types.ContextWrapper, ContractID string, ht *HTLC) error {
+	ctw = types.SwitchContextWrapper(p.pid, ctw)
+
+	Height, Index, err := types.ParseTransactionID(ContractID)
+	if err != nil {
+		return err
+	}
+	if ht == nil {
+		ctw.SetProcessData(toHTLCKey(Height, Index), nil)
+		return nil
+	}
+	bs, err := encoding.Marshal(ht)
+	if err != nil {
+		return err
+	}
+	ctw.SetProcessData(toHTLCKey(Height, Index), bs)
+	return nil
+}
@@ -2,6 +2,7 @@ package vault
 
 import (
 	"bytes"
+	"encoding/json"
 
 	"github.com/fletaio/fleta/common/amount"
 )
@@ -24,3 +25,55 @@ func (pc *Policy) MarshalJSON() ([]byte, error) {
 	buffer.WriteString(`}`)
 	return buffer.Bytes(), nil
 }
+
+// DynamicFeePolicy adjusts the base fee of the next block by the fullness of the previous block
+// the base fee moves by 1/AdjustmentDenominator of it at most when the block is empty or full
+type DynamicFeePolicy struct {
+	MinFee                  *amount.Amount
+	MaxFee                  *amount.Amount
+	TargetTransactions      uint32
+	MaxTransactionsPerBlock uint32
+	AdjustmentDenominator   uint32
+}
+
+// MarshalJSON is a marshaler function
+func (pc *DynamicFeePolicy) MarshalJSON() ([]byte, error) {
+	var buffer bytes.Buffer
+	buffer.WriteString(`{`)
+	buffer.WriteString(`"min_fee":`)
+	if bs, err := pc.MinFee.MarshalJSON(); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"max_fee":`)
+	if bs, err := pc.MaxFee.MarshalJSON(); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"target_transactions":`)
+	if bs, err := json.Marshal(pc.TargetTransactions); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"max_transactions_per_block":`)
+	if bs, err := json.Marshal(pc.MaxTransactionsPerBlock); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"adjustment_denominator":`)
+	if bs, err := json.Marshal(pc.AdjustmentDenominator); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`}`)
+	return buffer.Bytes(), nil
+}
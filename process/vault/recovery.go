@@ -0,0 +1,197 @@
+package vault
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/fletaio/fleta/common"
+	"github.com/fletaio/fleta/core/types"
+	"github.com/fletaio/fleta/encoding"
+)
+
+// RecoverableAccount is an account whose keys can be replaced by the recovery guardians
+type RecoverableAccount interface {
+	types.Account
+	ValidateRecoveryKeys(Required uint8, KeyHashes []common.PublicHash) error
+	ApplyRecoveryKeys(Required uint8, KeyHashes []common.PublicHash)
+}
+
+// RecoveryGuardian is the guardian set that can rotate keys of the account after the delay
+type RecoveryGuardian struct {
+	Required  uint8
+	Delay     uint32
+	KeyHashes []common.PublicHash
+}
+
+// Validate checks that signers contain the required number of guardians
+func (rg *RecoveryGuardian) Validate(signers []common.PublicHash) error {
+	signerMap := map[common.PublicHash]bool{}
+	for _, signer := range signers {
+		signerMap[signer] = true
+	}
+	matchCount := 0
+	for _, pubhash := range rg.KeyHashes {
+		if signerMap[pubhash] {
+			matchCount++
+		}
+	}
+	if matchCount < int(rg.Required) {
+		return types.ErrInvalidAccountSigner
+	}
+	return nil
+}
+
+// MarshalJSON is a marshaler function
+func (rg *RecoveryGuardian) MarshalJSON() ([]byte, error) {
+	var buffer bytes.Buffer
+	buffer.WriteString(`{`)
+	buffer.WriteString(`"required":`)
+	if bs, err := json.Marshal(rg.Required); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"delay":`)
+	if bs, err := json.Marshal(rg.Delay); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"key_hashes":`)
+	buffer.WriteString(`[`)
+	for i, pubhash := range rg.KeyHashes {
+		if i > 0 {
+			buffer.WriteString(`,`)
+		}
+		if bs, err := pubhash.MarshalJSON(); err != nil {
+			return nil, err
+		} else {
+			buffer.Write(bs)
+		}
+	}
+	buffer.WriteString(`]`)
+	buffer.WriteString(`}`)
+	return buffer.Bytes(), nil
+}
+
+// PendingRecovery is the key rotation requested by guardians
+type PendingRecovery struct {
+	ExecutableHeight uint32
+	Required         uint8
+	KeyHashes        []common.PublicHash
+}
+
+// MarshalJSON is a marshaler function
+func (pr *PendingRecovery) MarshalJSON() ([]byte, error) {
+	var buffer bytes.Buffer
+	buffer.WriteString(`{`)
+	buffer.WriteString(`"executable_height":`)
+	if bs, err := json.Marshal(pr.ExecutableHeight); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"required":`)
+	if bs, err := json.Marshal(pr.Required); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"key_hashes":`)
+	buffer.WriteString(`[`)
+	for i, pubhash := range pr.KeyHashes {
+		if i > 0 {
+			buffer.WriteString(`,`)
+		}
+		if bs, err := pubhash.MarshalJSON(); err != nil {
+			return nil, err
+		} else {
+			buffer.Write(bs)
+		}
+	}
+	buffer.WriteString(`]`)
+	buffer.WriteString(`}`)
+	return buffer.Bytes(), nil
+}
+
+// RecoveryGuardian returns the recovery guardian of the account
+func (p *Vault) RecoveryGuardian(loader types.Loader, addr common.Address) (*RecoveryGuardian, error) {
+	lw := types.NewLoaderWrapper(p.pid, loader)
+
+	bs := lw.AccountData(addr, tagRecoveryGuardian)
+	if len(bs) == 0 {
+		return nil, ErrNotExistRecoveryGuardian
+	}
+	rg := &RecoveryGuardian{}
+	if err := encoding.Unmarshal(bs, &rg); err != nil {
+		return nil, err
+	}
+	return rg, nil
+}
+
+func (p *Vault) setRecoveryGuardian(ctw *types.ContextWrapper, addr common.Address, rg *RecoveryGuardian) error {
+	ctw = types.SwitchContextWrapper(p.pid, ctw)
+
+	if rg == nil {
+		ctw.SetAccountData(addr, tagRecoveryGuardian, nil)
+		return nil
+	}
+	bs, err := encoding.Marshal(rg)
+	if err != nil {
+		return err
+	}
+	ctw.SetAccountData(addr, tagRecoveryGuardian, bs)
+	return nil
+}
+
+// PendingRecovery returns the pending recovery of the account
+func (p *Vault) PendingRecovery(loader types.Loader, addr common.Address) (*PendingRecovery, error) {
+	lw := types.NewLoaderWrapper(p.pid, loader)
+
+	bs := lw.AccountData(addr, tagPendingRecovery)
+	if len(bs) == 0 {
+		return nil, ErrNotExistPendingRecovery
+	}
+	pr := &PendingRecovery{}
+	if err := encoding.Unmarshal(bs, &pr); err != nil {
+		return nil, err
+	}
+	return pr, nil
+}
+
+func (p *Vault) setPendingRecovery(ctw *types.ContextWrapper, addr common.Address, pr *PendingRecovery) error {
+	ctw = types.SwitchContextWrapper(p.pid, ctw)
+
+	if pr == nil {
+		ctw.SetAccountData(addr, tagPendingRecovery, nil)
+		return nil
+	}
+	bs, err := encoding.Marshal(pr)
+	if err != nil {
+		return err
+	}
+	ctw.SetAccountData(addr, tagPendingRecovery, bs)
+	return nil
+}
+
+// ValidateKeyHashes checks that key hashes are distinct and the required count is in the range
+func ValidateKeyHashes(Required uint8, KeyHashes []common.PublicHash, MaxCount int) error {
+	if len(KeyHashes) < 1 || len(KeyHashes) > MaxCount {
+		return ErrInvalidMultiKeyHashCount
+	}
+	if Required < 1 || int(Required) > len(KeyHashes) {
+		return ErrInvalidRequiredKeyHashCount
+	}
+	keyHashMap := map[common.PublicHash]bool{}
+	for _, v := range KeyHashes {
+		keyHashMap[v] = true
+	}
+	if len(keyHashMap) != len(KeyHashes) {
+		return ErrInvalidMultiKeyHashCount
+	}
+	return nil
+}
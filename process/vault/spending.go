@@ -0,0 +1,246 @@
+package vault
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/fletaio/fleta/common"
+	"github.com/fletaio/fleta/common/amount"
+	"github.com/fletaio/fleta/common/binutil"
+	"github.com/fletaio/fleta/core/types"
+	"github.com/fletaio/fleta/encoding"
+)
+
+// SpendingPolicyDelay is the number of blocks to wait until the changed spending policy is applied
+const SpendingPolicyDelay = uint32(172800)
+
+// SpendingPolicy limits outflows of the account
+// Limit is the maximum outflow in the window of Window blocks and zero Limit means no limit
+// empty AllowList means that any destination is allowed
+type SpendingPolicy struct {
+	Limit     *amount.Amount
+	Window    uint32
+	AllowList []common.Address
+}
+
+// IsEmpty returns true when the policy does not limit anything
+func (sp *SpendingPolicy) IsEmpty() bool {
+	return sp.Limit.IsZero() && len(sp.AllowList) == 0
+}
+
+// IsAllowed returns true when the destination is allowed
+func (sp *SpendingPolicy) IsAllowed(To common.Address) bool {
+	if len(sp.AllowList) == 0 {
+		return true
+	}
+	for _, addr := range sp.AllowList {
+		if addr == To {
+			return true
+		}
+	}
+	return false
+}
+
+// MarshalJSON is a marshaler function
+func (sp *SpendingPolicy) MarshalJSON() ([]byte, error) {
+	var buffer bytes.Buffer
+	buffer.WriteString(`{`)
+	buffer.WriteString(`"limit":`)
+	if bs, err := sp.Limit.MarshalJSON(); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"window":`)
+	if bs, err := json.Marshal(sp.Window); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"allow_list":`)
+	buffer.WriteString(`[`)
+	for i, addr := range sp.AllowList {
+		if i > 0 {
+			buffer.WriteString(`,`)
+		}
+		if bs, err := addr.MarshalJSON(); err != nil {
+			return nil, err
+		} else {
+			buffer.Write(bs)
+		}
+	}
+	buffer.WriteString(`]`)
+	buffer.WriteString(`}`)
+	return buffer.Bytes(), nil
+}
+
+// PendingSpendingPolicy is the spending policy that will be applied at the activate height
+type PendingSpendingPolicy struct {
+	ActivateHeight uint32
+	Policy         *SpendingPolicy
+}
+
+// MarshalJSON is a marshaler function
+func (pp *PendingSpendingPolicy) MarshalJSON() ([]byte, error) {
+	var buffer bytes.Buffer
+	buffer.WriteString(`{`)
+	buffer.WriteString(`"activate_height":`)
+	if bs, err := json.Marshal(pp.ActivateHeight); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"policy":`)
+	if bs, err := pp.Policy.MarshalJSON(); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`}`)
+	return buffer.Bytes(), nil
+}
+
+// SpendingPolicy returns the spending policy of the account applied at the target height
+func (p *Vault) SpendingPolicy(loader types.Loader, addr common.Address) (*SpendingPolicy, error) {
+	lw := types.NewLoaderWrapper(p.pid, loader)
+
+	if pp, err := p.PendingSpendingPolicy(lw, addr); err != nil {
+		if err != ErrNotExistSpendingPolicy {
+			return nil, err
+		}
+	} else if pp.ActivateHeight <= lw.TargetHeight() {
+		if pp.Policy.IsEmpty() {
+			return nil, ErrNotExistSpendingPolicy
+		}
+		return pp.Policy, nil
+	}
+
+	bs := lw.AccountData(addr, tagSpendingPolicy)
+	if len(bs) == 0 {
+		return nil, ErrNotExistSpendingPolicy
+	}
+	policy := &SpendingPolicy{}
+	if err := encoding.Unmarshal(bs, &policy); err != nil {
+		return nil, err
+	}
+	return policy, nil
+}
+
+// PendingSpendingPolicy returns the spending policy that waits the delay
+func (p *Vault) PendingSpendingPolicy(loader types.Loader, addr common.Address) (*PendingSpendingPolicy, error) {
+	lw := types.NewLoaderWrapper(p.pid, loader)
+
+	bs := lw.AccountData(addr, tagPendingSpending)
+	if len(bs) == 0 {
+		return nil, ErrNotExistSpendingPolicy
+	}
+	pp := &PendingSpendingPolicy{}
+	if err := encoding.Unmarshal(bs, &pp); err != nil {
+		return nil, err
+	}
+	return pp, nil
+}
+
+// SpentAmount returns the outflow of the account in the current window
+func (p *Vault) SpentAmount(loader types.Loader, addr common.Address, Window uint32) *amount.Amount {
+	lw := types.NewLoaderWrapper(p.pid, loader)
+
+	bs := lw.AccountData(addr, tagSpentAmount)
+	if len(bs) < 4 || Window == 0 {
+		return amount.NewCoinAmount(0, 0)
+	}
+	if binutil.BigEndian.Uint32(bs) != lw.TargetHeight()/Window {
+		return amount.NewCoinAmount(0, 0)
+	}
+	return amount.NewAmountFromBytes(bs[4:])
+}
+
+// CheckSpending returns an error when the outflow violates the spending policy of the account
+// ToAddresses is empty when coins are not sent to other accounts like burning
+// transactions that debit the account call it in Validate to check destinations because SubBalance only knows the amount
+func (p *Vault) CheckSpending(loader types.Loader, addr common.Address, ToAddresses []common.Address, am *amount.Amount) error {
+	policy, err := p.SpendingPolicy(loader, addr)
+	if err != nil {
+		if err == ErrNotExistSpendingPolicy {
+			return nil
+		}
+		return err
+	}
+	for _, To := range ToAddresses {
+		if !policy.IsAllowed(To) {
+			return ErrNotAllowedDestination
+		}
+	}
+	if !policy.Limit.IsZero() {
+		if policy.Limit.Less(p.SpentAmount(loader, addr, policy.Window).Add(am)) {
+			return ErrExceedSpendingLimit
+		}
+	}
+	return nil
+}
+
+// addSpending checks the limit of the spending policy and accumulates the outflow of the account
+// it is called by SubBalance so every debit of the account is limited including fees and costs of other processes
+func (p *Vault) addSpending(ctw *types.ContextWrapper, addr common.Address, am *amount.Amount) error {
+	ctw = types.SwitchContextWrapper(p.pid, ctw)
+
+	policy, err := p.SpendingPolicy(ctw, addr)
+	if err != nil {
+		if err == ErrNotExistSpendingPolicy {
+			return nil
+		}
+		return err
+	}
+	if policy.Limit.IsZero() {
+		return nil
+	}
+	spent := p.SpentAmount(ctw, addr, policy.Window).Add(am)
+	if policy.Limit.Less(spent) {
+		return ErrExceedSpendingLimit
+	}
+	bs := make([]byte, 4, 4+len(spent.Bytes()))
+	binutil.BigEndian.PutUint32(bs, ctw.TargetHeight()/policy.Window)
+	bs = append(bs, spent.Bytes()...)
+	ctw.SetAccountData(addr, tagSpentAmount, bs)
+	return nil
+}
+
+func (p *Vault) updateSpendingPolicy(ctw *types.ContextWrapper, addr common.Address, policy *SpendingPolicy) error {
+	ctw = types.SwitchContextWrapper(p.pid, ctw)
+
+	current, err := p.SpendingPolicy(ctw, addr)
+	if err != nil && err != ErrNotExistSpendingPolicy {
+		return err
+	}
+	ctw.SetAccountData(addr, tagPendingSpending, nil)
+	if current == nil {
+		// a new policy only restricts the account so it is applied without the delay
+		if policy.IsEmpty() {
+			ctw.SetAccountData(addr, tagSpendingPolicy, nil)
+			return nil
+		}
+		bs, err := encoding.Marshal(policy)
+		if err != nil {
+			return err
+		}
+		ctw.SetAccountData(addr, tagSpendingPolicy, bs)
+		return nil
+	}
+	if bs, err := encoding.Marshal(current); err != nil {
+		return err
+	} else {
+		ctw.SetAccountData(addr, tagSpendingPolicy, bs)
+	}
+	bs, err := encoding.Marshal(&PendingSpendingPolicy{
+		ActivateHeight: ctw.TargetHeight() + SpendingPolicyDelay,
+		Policy:         policy,
+	})
+	if err != nil {
+		return err
+	}
+	ctw.SetAccountData(addr, tagPendingSpending, bs)
+	return nil
+}
@@ -54,6 +54,9 @@ func (tx *Burn) Validate(p types.Process, loader types.LoaderWrapper, signers []
 		return err
 	}
 
+	if err := sp.CheckSpending(loader, tx.From(), nil, tx.Amount); err != nil {
+		return err
+	}
 	if err := sp.CheckFeePayableWith(p, loader, tx, tx.Amount); err != nil {
 		return err
 	}
@@ -0,0 +1,133 @@
+package vault
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/fletaio/fleta/common"
+	"github.com/fletaio/fleta/common/amount"
+	"github.com/fletaio/fleta/core/types"
+)
+
+// ChangeMultiKey replaces keys and the required signer count of the multi account
+type ChangeMultiKey struct {
+	Timestamp_ uint64
+	Seq_       uint64
+	From_      common.Address
+	Required   uint8
+	KeyHashes  []common.PublicHash
+}
+
+// Timestamp returns the timestamp of the transaction
+func (tx *ChangeMultiKey) Timestamp() uint64 {
+	return tx.Timestamp_
+}
+
+// Seq returns the sequence of the transaction
+func (tx *ChangeMultiKey) Seq() uint64 {
+	return tx.Seq_
+}
+
+// From returns the from address of the transaction
+func (tx *ChangeMultiKey) From() common.Address {
+	return tx.From_
+}
+
+// Fee returns the fee of the transaction
+func (tx *ChangeMultiKey) Fee(p types.Process, loader types.LoaderWrapper) *amount.Amount {
+	sp := p.(*Vault)
+	return sp.GetDefaultFee(loader)
+}
+
+// Validate validates signatures of the transaction
+func (tx *ChangeMultiKey) Validate(p types.Process, loader types.LoaderWrapper, signers []common.PublicHash) error {
+	sp := p.(*Vault)
+
+	if tx.Seq() <= loader.Seq(tx.From()) {
+		return types.ErrInvalidSequence
+	}
+
+	acc, err := loader.Account(tx.From())
+	if err != nil {
+		return err
+	}
+	multiAcc, is := acc.(*MultiAccount)
+	if !is {
+		return types.ErrInvalidAccountType
+	}
+	if err := multiAcc.Validate(loader, signers); err != nil {
+		return err
+	}
+	if err := multiAcc.ValidateRecoveryKeys(tx.Required, tx.KeyHashes); err != nil {
+		return err
+	}
+
+	if err := sp.CheckFeePayable(p, loader, tx); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Execute updates the context by the transaction
+func (tx *ChangeMultiKey) Execute(p types.Process, ctw *types.ContextWrapper, index uint16) error {
+	sp := p.(*Vault)
+
+	return sp.WithFee(p, ctw, tx, func() error {
+		acc, err := ctw.Account(tx.From())
+		if err != nil {
+			return err
+		}
+		multiAcc := acc.(*MultiAccount)
+		multiAcc.ApplyRecoveryKeys(tx.Required, tx.KeyHashes)
+		return nil
+	})
+}
+
+// MarshalJSON is a marshaler function
+func (tx *ChangeMultiKey) MarshalJSON() ([]byte, error) {
+	var buffer bytes.Buffer
+	buffer.WriteString(`{`)
+	buffer.WriteString(`"timestamp":`)
+	if bs, err := json.Marshal(tx.Timestamp_); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"seq":`)
+	if bs, err := json.Marshal(tx.Seq_); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"from":`)
+	if bs, err := tx.From_.MarshalJSON(); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"required":`)
+	if bs, err := json.Marshal(tx.Required); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"key_hashes":`)
+	buffer.WriteString(`[`)
+	for i, pubhash := range tx.KeyHashes {
+		if i > 0 {
+			buffer.WriteString(`,`)
+		}
+		if bs, err := pubhash.MarshalJSON(); err != nil {
+			return nil, err
+		} else {
+			buffer.Write(bs)
+		}
+	}
+	buffer.WriteString(`]`)
+	buffer.WriteString(`}`)
+	return buffer.Bytes(), nil
+}
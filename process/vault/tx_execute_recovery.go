@@ -0,0 +1,125 @@
+package vault
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/fletaio/fleta/common"
+	"github.com/fletaio/fleta/common/amount"
+	"github.com/fletaio/fleta/core/types"
+)
+
+// ExecuteRecovery applies keys of the pending recovery after the delay
+// it is signed by guardians of the account
+type ExecuteRecovery struct {
+	Timestamp_ uint64
+	Seq_       uint64
+	From_      common.Address
+}
+
+// Timestamp returns the timestamp of the transaction
+func (tx *ExecuteRecovery) Timestamp() uint64 {
+	return tx.Timestamp_
+}
+
+// Seq returns the sequence of the transaction
+func (tx *ExecuteRecovery) Seq() uint64 {
+	return tx.Seq_
+}
+
+// From returns the from address of the transaction
+func (tx *ExecuteRecovery) From() common.Address {
+	return tx.From_
+}
+
+// Fee returns the fee of the transaction
+func (tx *ExecuteRecovery) Fee(p types.Process, loader types.LoaderWrapper) *amount.Amount {
+	sp := p.(*Vault)
+	return sp.GetDefaultFee(loader)
+}
+
+// Validate validates signatures of the transaction
+func (tx *ExecuteRecovery) Validate(p types.Process, loader types.LoaderWrapper, signers []common.PublicHash) error {
+	sp := p.(*Vault)
+
+	if tx.Seq() <= loader.Seq(tx.From()) {
+		return types.ErrInvalidSequence
+	}
+
+	acc, err := loader.Account(tx.From())
+	if err != nil {
+		return err
+	}
+	recAcc, is := acc.(RecoverableAccount)
+	if !is {
+		return ErrNotRecoverableAccount
+	}
+	rg, err := sp.RecoveryGuardian(loader, tx.From())
+	if err != nil {
+		return err
+	}
+	if err := rg.Validate(signers); err != nil {
+		return err
+	}
+	pr, err := sp.PendingRecovery(loader, tx.From())
+	if err != nil {
+		return err
+	}
+	if loader.TargetHeight() < pr.ExecutableHeight {
+		return ErrRecoveryDelayNotPassed
+	}
+	if err := recAcc.ValidateRecoveryKeys(pr.Required, pr.KeyHashes); err != nil {
+		return err
+	}
+
+	if err := sp.CheckFeePayable(p, loader, tx); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Execute updates the context by the transaction
+func (tx *ExecuteRecovery) Execute(p types.Process, ctw *types.ContextWrapper, index uint16) error {
+	sp := p.(*Vault)
+
+	return sp.WithFee(p, ctw, tx, func() error {
+		pr, err := sp.PendingRecovery(ctw, tx.From())
+		if err != nil {
+			return err
+		}
+		acc, err := ctw.Account(tx.From())
+		if err != nil {
+			return err
+		}
+		acc.(RecoverableAccount).ApplyRecoveryKeys(pr.Required, pr.KeyHashes)
+		return sp.setPendingRecovery(ctw, tx.From(), nil)
+	})
+}
+
+// MarshalJSON is a marshaler function
+func (tx *ExecuteRecovery) MarshalJSON() ([]byte, error) {
+	var buffer bytes.Buffer
+	buffer.WriteString(`{`)
+	buffer.WriteString(`"timestamp":`)
+	if bs, err := json.Marshal(tx.Timestamp_); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"seq":`)
+	if bs, err := json.Marshal(tx.Seq_); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"from":`)
+	if bs, err := tx.From_.MarshalJSON(); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`}`)
+	return buffer.Bytes(), nil
+}
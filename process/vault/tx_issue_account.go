@@ -37,7 +37,9 @@ func (tx *IssueAccount) From() common.Address {
 func (tx *IssueAccount) Validate(p types.Process, loader types.LoaderWrapper, signers []common.PublicHash) error {
 	sp := p.(*Vault)
 
-	if tx.From() != sp.admin.AdminAddress(loader, p.Name()) {
+	if has, err := sp.admin.HasRole(loader, p.Name(), admin.RoleIssueAccount, tx.From()); err != nil {
+		return err
+	} else if !has {
 		return admin.ErrUnauthorizedTransaction
 	}
 
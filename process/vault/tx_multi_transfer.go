@@ -0,0 +1,183 @@
+package vault
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/fletaio/fleta/common"
+	"github.com/fletaio/fleta/common/amount"
+	"github.com/fletaio/fleta/core/types"
+)
+
+// MultiTransfer sends amounts to multiple addresses in a transaction
+// Tags is optional but its length should be same with ToAddresses when it is used
+type MultiTransfer struct {
+	Timestamp_  uint64
+	Seq_        uint64
+	From_       common.Address
+	ToAddresses []common.Address
+	Amounts     []*amount.Amount
+	Tags        []string
+}
+
+// Timestamp returns the timestamp of the transaction
+func (tx *MultiTransfer) Timestamp() uint64 {
+	return tx.Timestamp_
+}
+
+// Seq returns the sequence of the transaction
+func (tx *MultiTransfer) Seq() uint64 {
+	return tx.Seq_
+}
+
+// From returns the from address of the transaction
+func (tx *MultiTransfer) From() common.Address {
+	return tx.From_
+}
+
+// Fee returns the fee of the transaction
+func (tx *MultiTransfer) Fee(p types.Process, loader types.LoaderWrapper) *amount.Amount {
+	sp := p.(*Vault)
+	return sp.GetDefaultFee(loader).MulC(int64(len(tx.ToAddresses)))
+}
+
+// TotalAmount returns the sum of amounts of the transaction
+func (tx *MultiTransfer) TotalAmount() *amount.Amount {
+	sum := amount.NewCoinAmount(0, 0)
+	for _, am := range tx.Amounts {
+		sum = sum.Add(am)
+	}
+	return sum
+}
+
+// Validate validates signatures of the transaction
+func (tx *MultiTransfer) Validate(p types.Process, loader types.LoaderWrapper, signers []common.PublicHash) error {
+	sp := p.(*Vault)
+
+	if len(tx.ToAddresses) == 0 || len(tx.ToAddresses) > 500 {
+		return ErrInvalidOutputCount
+	}
+	if len(tx.Amounts) != len(tx.ToAddresses) {
+		return ErrInvalidOutputCount
+	}
+	if len(tx.Tags) > 0 && len(tx.Tags) != len(tx.ToAddresses) {
+		return ErrInvalidOutputCount
+	}
+	for _, Tag := range tx.Tags {
+		if len(Tag) > 32 {
+			return ErrInvalidTagSize
+		}
+	}
+	for _, am := range tx.Amounts {
+		if am.Less(amount.COIN.DivC(10)) {
+			return types.ErrDustAmount
+		}
+	}
+	if tx.Seq() <= loader.Seq(tx.From()) {
+		return types.ErrInvalidSequence
+	}
+
+	for _, To := range tx.ToAddresses {
+		if has, err := loader.HasAccount(To); err != nil {
+			return err
+		} else if !has {
+			return types.ErrNotExistAccount
+		}
+	}
+
+	fromAcc, err := loader.Account(tx.From())
+	if err != nil {
+		return err
+	}
+	if err := fromAcc.Validate(loader, signers); err != nil {
+		return err
+	}
+
+	if err := sp.CheckSpending(loader, tx.From(), tx.ToAddresses, tx.TotalAmount()); err != nil {
+		return err
+	}
+	if err := sp.CheckFeePayableWith(p, loader, tx, tx.TotalAmount()); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Execute updates the context by the transaction
+func (tx *MultiTransfer) Execute(p types.Process, ctw *types.ContextWrapper, index uint16) error {
+	sp := p.(*Vault)
+
+	return sp.WithFee(p, ctw, tx, func() error {
+		if err := sp.SubBalance(ctw, tx.From(), tx.TotalAmount()); err != nil {
+			return err
+		}
+		for i, To := range tx.ToAddresses {
+			if err := sp.AddBalance(ctw, To, tx.Amounts[i]); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// MarshalJSON is a marshaler function
+func (tx *MultiTransfer) MarshalJSON() ([]byte, error) {
+	var buffer bytes.Buffer
+	buffer.WriteString(`{`)
+	buffer.WriteString(`"timestamp":`)
+	if bs, err := json.Marshal(tx.Timestamp_); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"seq":`)
+	if bs, err := json.Marshal(tx.Seq_); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"from":`)
+	if bs, err := tx.From_.MarshalJSON(); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"to_addresses":`)
+	buffer.WriteString(`[`)
+	for i, To := range tx.ToAddresses {
+		if i > 0 {
+			buffer.WriteString(`,`)
+		}
+		if bs, err := To.MarshalJSON(); err != nil {
+			return nil, err
+		} else {
+			buffer.Write(bs)
+		}
+	}
+	buffer.WriteString(`]`)
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"amounts":`)
+	buffer.WriteString(`[`)
+	for i, am := range tx.Amounts {
+		if i > 0 {
+			buffer.WriteString(`,`)
+		}
+		if bs, err := am.MarshalJSON(); err != nil {
+			return nil, err
+		} else {
+			buffer.Write(bs)
+		}
+	}
+	buffer.WriteString(`]`)
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"tags":`)
+	if bs, err := json.Marshal(tx.Tags); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`}`)
+	return buffer.Bytes(), nil
+}
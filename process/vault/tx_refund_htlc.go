@@ -0,0 +1,132 @@
+package vault
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/fletaio/fleta/common"
+	"github.com/fletaio/fleta/common/amount"
+	"github.com/fletaio/fleta/core/types"
+)
+
+// RefundHTLC returns the amount of the expired contract to the sender
+type RefundHTLC struct {
+	Timestamp_ uint64
+	Seq_       uint64
+	From_      common.Address
+	ContractID string
+}
+
+// Timestamp returns the timestamp of the transaction
+func (tx *RefundHTLC) Timestamp() uint64 {
+	return tx.Timestamp_
+}
+
+// Seq returns the sequence of the transaction
+func (tx *RefundHTLC) Seq() uint64 {
+	return tx.Seq_
+}
+
+// From returns the from address of the transaction
+func (tx *RefundHTLC) From() common.Address {
+	return tx.From_
+}
+
+// Fee returns the fee of the transaction
+func (tx *RefundHTLC) Fee(p types.Process, loader types.LoaderWrapper) *amount.Amount {
+	sp := p.(*Vault)
+	return sp.GetDefaultFee(loader)
+}
+
+// Validate validates signatures of the transaction
+func (tx *RefundHTLC) Validate(p types.Process, loader types.LoaderWrapper, signers []common.PublicHash) error {
+	sp := p.(*Vault)
+
+	if tx.Seq() <= loader.Seq(tx.From()) {
+		return types.ErrInvalidSequence
+	}
+
+	ht, err := sp.HTLC(loader, tx.ContractID)
+	if err != nil {
+		return err
+	}
+	if ht.From != tx.From() {
+		return ErrNotHTLCSender
+	}
+	if loader.TargetHeight() < ht.ExpireHeight {
+		return ErrNotExpiredHTLC
+	}
+
+	fromAcc, err := loader.Account(tx.From())
+	if err != nil {
+		return err
+	}
+	if err := fromAcc.Validate(loader, signers); err != nil {
+		return err
+	}
+
+	if err := sp.CheckFeePayable(p, loader, tx); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Execute updates the context by the transaction
+func (tx *RefundHTLC) Execute(p types.Process, ctw *types.ContextWrapper, index uint16) error {
+	sp := p.(*Vault)
+
+	return sp.WithFee(p, ctw, tx, func() error {
+		ht, err := sp.HTLC(ctw, tx.ContractID)
+		if err != nil {
+			return err
+		}
+		if err := sp.AddBalance(ctw, ht.From, ht.Amount); err != nil {
+			return err
+		}
+		if err := sp.setHTLC(ctw, tx.ContractID, nil); err != nil {
+			return err
+		}
+		return ctw.EmitEvent(&HTLCRefundedEvent{
+			Height_:    ctw.TargetHeight(),
+			Index_:     index,
+			ContractID: tx.ContractID,
+			From:       ht.From,
+			Amount:     ht.Amount,
+		})
+	})
+}
+
+// MarshalJSON is a marshaler function
+func (tx *RefundHTLC) MarshalJSON() ([]byte, error) {
+	var buffer bytes.Buffer
+	buffer.WriteString(`{`)
+	buffer.WriteString(`"timestamp":`)
+	if bs, err := json.Marshal(tx.Timestamp_); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"seq":`)
+	if bs, err := json.Marshal(tx.Seq_); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"from":`)
+	if bs, err := tx.From_.MarshalJSON(); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"contract_id":`)
+	if bs, err := json.Marshal(tx.ContractID); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`}`)
+	return buffer.Bytes(), nil
+}
@@ -0,0 +1,145 @@
+package vault
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/fletaio/fleta/common"
+	"github.com/fletaio/fleta/common/amount"
+	"github.com/fletaio/fleta/core/types"
+)
+
+// RequestRecovery requests the key rotation of the account by its guardians
+// it is signed by guardians and can be executed after the delay of the guardian set
+type RequestRecovery struct {
+	Timestamp_ uint64
+	Seq_       uint64
+	From_      common.Address
+	Required   uint8
+	KeyHashes  []common.PublicHash
+}
+
+// Timestamp returns the timestamp of the transaction
+func (tx *RequestRecovery) Timestamp() uint64 {
+	return tx.Timestamp_
+}
+
+// Seq returns the sequence of the transaction
+func (tx *RequestRecovery) Seq() uint64 {
+	return tx.Seq_
+}
+
+// From returns the from address of the transaction
+func (tx *RequestRecovery) From() common.Address {
+	return tx.From_
+}
+
+// Fee returns the fee of the transaction
+func (tx *RequestRecovery) Fee(p types.Process, loader types.LoaderWrapper) *amount.Amount {
+	sp := p.(*Vault)
+	return sp.GetDefaultFee(loader)
+}
+
+// Validate validates signatures of the transaction
+func (tx *RequestRecovery) Validate(p types.Process, loader types.LoaderWrapper, signers []common.PublicHash) error {
+	sp := p.(*Vault)
+
+	if tx.Seq() <= loader.Seq(tx.From()) {
+		return types.ErrInvalidSequence
+	}
+
+	acc, err := loader.Account(tx.From())
+	if err != nil {
+		return err
+	}
+	recAcc, is := acc.(RecoverableAccount)
+	if !is {
+		return ErrNotRecoverableAccount
+	}
+	rg, err := sp.RecoveryGuardian(loader, tx.From())
+	if err != nil {
+		return err
+	}
+	if err := rg.Validate(signers); err != nil {
+		return err
+	}
+	if _, err := sp.PendingRecovery(loader, tx.From()); err == nil {
+		return ErrExistPendingRecovery
+	} else if err != ErrNotExistPendingRecovery {
+		return err
+	}
+	if err := recAcc.ValidateRecoveryKeys(tx.Required, tx.KeyHashes); err != nil {
+		return err
+	}
+
+	if err := sp.CheckFeePayable(p, loader, tx); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Execute updates the context by the transaction
+func (tx *RequestRecovery) Execute(p types.Process, ctw *types.ContextWrapper, index uint16) error {
+	sp := p.(*Vault)
+
+	return sp.WithFee(p, ctw, tx, func() error {
+		rg, err := sp.RecoveryGuardian(ctw, tx.From())
+		if err != nil {
+			return err
+		}
+		return sp.setPendingRecovery(ctw, tx.From(), &PendingRecovery{
+			ExecutableHeight: ctw.TargetHeight() + rg.Delay,
+			Required:         tx.Required,
+			KeyHashes:        tx.KeyHashes,
+		})
+	})
+}
+
+// MarshalJSON is a marshaler function
+func (tx *RequestRecovery) MarshalJSON() ([]byte, error) {
+	var buffer bytes.Buffer
+	buffer.WriteString(`{`)
+	buffer.WriteString(`"timestamp":`)
+	if bs, err := json.Marshal(tx.Timestamp_); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"seq":`)
+	if bs, err := json.Marshal(tx.Seq_); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"from":`)
+	if bs, err := tx.From_.MarshalJSON(); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"required":`)
+	if bs, err := json.Marshal(tx.Required); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"key_hashes":`)
+	buffer.WriteString(`[`)
+	for i, pubhash := range tx.KeyHashes {
+		if i > 0 {
+			buffer.WriteString(`,`)
+		}
+		if bs, err := pubhash.MarshalJSON(); err != nil {
+			return nil, err
+		} else {
+			buffer.Write(bs)
+		}
+	}
+	buffer.WriteString(`]`)
+	buffer.WriteString(`}`)
+	return buffer.Bytes(), nil
+}
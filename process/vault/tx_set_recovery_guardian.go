@@ -0,0 +1,151 @@
+package vault
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/fletaio/fleta/common"
+	"github.com/fletaio/fleta/common/amount"
+	"github.com/fletaio/fleta/core/types"
+)
+
+// SetRecoveryGuardian sets guardians that can rotate keys of the account after the delay
+// empty KeyHashes removes the guardian set
+type SetRecoveryGuardian struct {
+	Timestamp_ uint64
+	Seq_       uint64
+	From_      common.Address
+	Required   uint8
+	Delay      uint32
+	KeyHashes  []common.PublicHash
+}
+
+// Timestamp returns the timestamp of the transaction
+func (tx *SetRecoveryGuardian) Timestamp() uint64 {
+	return tx.Timestamp_
+}
+
+// Seq returns the sequence of the transaction
+func (tx *SetRecoveryGuardian) Seq() uint64 {
+	return tx.Seq_
+}
+
+// From returns the from address of the transaction
+func (tx *SetRecoveryGuardian) From() common.Address {
+	return tx.From_
+}
+
+// Fee returns the fee of the transaction
+func (tx *SetRecoveryGuardian) Fee(p types.Process, loader types.LoaderWrapper) *amount.Amount {
+	sp := p.(*Vault)
+	return sp.GetDefaultFee(loader)
+}
+
+// Validate validates signatures of the transaction
+func (tx *SetRecoveryGuardian) Validate(p types.Process, loader types.LoaderWrapper, signers []common.PublicHash) error {
+	sp := p.(*Vault)
+
+	if tx.Seq() <= loader.Seq(tx.From()) {
+		return types.ErrInvalidSequence
+	}
+
+	acc, err := loader.Account(tx.From())
+	if err != nil {
+		return err
+	}
+	recAcc, is := acc.(RecoverableAccount)
+	if !is {
+		return ErrNotRecoverableAccount
+	}
+	if err := recAcc.Validate(loader, signers); err != nil {
+		return err
+	}
+	if len(tx.KeyHashes) > 0 {
+		if err := ValidateKeyHashes(tx.Required, tx.KeyHashes, 10); err != nil {
+			return err
+		}
+		if tx.Delay < 1 {
+			return ErrInvalidRecoveryDelay
+		}
+	}
+
+	if err := sp.CheckFeePayable(p, loader, tx); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Execute updates the context by the transaction
+func (tx *SetRecoveryGuardian) Execute(p types.Process, ctw *types.ContextWrapper, index uint16) error {
+	sp := p.(*Vault)
+
+	return sp.WithFee(p, ctw, tx, func() error {
+		if err := sp.setPendingRecovery(ctw, tx.From(), nil); err != nil {
+			return err
+		}
+		if len(tx.KeyHashes) == 0 {
+			return sp.setRecoveryGuardian(ctw, tx.From(), nil)
+		}
+		return sp.setRecoveryGuardian(ctw, tx.From(), &RecoveryGuardian{
+			Required:  tx.Required,
+			Delay:     tx.Delay,
+			KeyHashes: tx.KeyHashes,
+		})
+	})
+}
+
+// MarshalJSON is a marshaler function
+func (tx *SetRecoveryGuardian) MarshalJSON() ([]byte, error) {
+	var buffer bytes.Buffer
+	buffer.WriteString(`{`)
+	buffer.WriteString(`"timestamp":`)
+	if bs, err := json.Marshal(tx.Timestamp_); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"seq":`)
+	if bs, err := json.Marshal(tx.Seq_); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"from":`)
+	if bs, err := tx.From_.MarshalJSON(); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"required":`)
+	if bs, err := json.Marshal(tx.Required); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"delay":`)
+	if bs, err := json.Marshal(tx.Delay); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"key_hashes":`)
+	buffer.WriteString(`[`)
+	for i, pubhash := range tx.KeyHashes {
+		if i > 0 {
+			buffer.WriteString(`,`)
+		}
+		if bs, err := pubhash.MarshalJSON(); err != nil {
+			return nil, err
+		} else {
+			buffer.Write(bs)
+		}
+	}
+	buffer.WriteString(`]`)
+	buffer.WriteString(`}`)
+	return buffer.Bytes(), nil
+}
@@ -0,0 +1,184 @@
+package vault
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+
+	"github.com/fletaio/fleta/common"
+	"github.com/fletaio/fleta/common/amount"
+	"github.com/fletaio/fleta/core/chain"
+	"github.com/fletaio/fleta/core/types"
+	"github.com/fletaio/fleta/encoding"
+)
+
+// Sponsored is an envelope of the vault transaction whose fee is paid by the payer
+// the last PayerSignerCount signatures are signatures of the payer and others are signatures of the sender
+// zero PayerSignerCount means a single signature of the payer
+type Sponsored struct {
+	Timestamp_       uint64
+	Seq_             uint64
+	From_            common.Address
+	Payer            common.Address
+	TxType           uint16
+	TxData           []byte
+	PayerSignerCount uint8 `msgpack:",omitempty"`
+}
+
+// Timestamp returns the timestamp of the transaction
+func (tx *Sponsored) Timestamp() uint64 {
+	return tx.Timestamp_
+}
+
+// Seq returns the sequence of the transaction
+func (tx *Sponsored) Seq() uint64 {
+	return tx.Seq_
+}
+
+// From returns the from address of the transaction
+func (tx *Sponsored) From() common.Address {
+	return tx.From_
+}
+
+// Fee returns the fee of the transaction
+func (tx *Sponsored) Fee(p types.Process, loader types.LoaderWrapper) *amount.Amount {
+	sp := p.(*Vault)
+	itx, err := tx.Transaction(sp)
+	if err != nil {
+		return amount.NewCoinAmount(0, 0)
+	}
+	return itx.Fee(p, loader)
+}
+
+// Validate validates signatures of the transaction
+func (tx *Sponsored) Validate(p types.Process, loader types.LoaderWrapper, signers []common.PublicHash) error {
+	sp := p.(*Vault)
+
+	if tx.Payer == tx.From() {
+		return ErrSelfSponsored
+	}
+	PayerSignerCount := int(tx.PayerSignerCount)
+	if PayerSignerCount == 0 {
+		PayerSignerCount = 1
+	}
+	if len(signers) <= PayerSignerCount {
+		return types.ErrInvalidSignerCount
+	}
+	SenderSignerCount := len(signers) - PayerSignerCount
+	itx, err := tx.Transaction(sp)
+	if err != nil {
+		return err
+	}
+
+	payerAcc, err := loader.Account(tx.Payer)
+	if err != nil {
+		return err
+	}
+	if err := payerAcc.Validate(loader, signers[SenderSignerCount:]); err != nil {
+		return err
+	}
+
+	return sp.withSponsor(itx, tx.Payer, func() error {
+		return itx.Validate(p, loader, signers[:SenderSignerCount])
+	})
+}
+
+// Execute updates the context by the transaction
+func (tx *Sponsored) Execute(p types.Process, ctw *types.ContextWrapper, index uint16) error {
+	sp := p.(*Vault)
+
+	itx, err := tx.Transaction(sp)
+	if err != nil {
+		return err
+	}
+	return sp.withSponsor(itx, tx.Payer, func() error {
+		return itx.Execute(p, ctw, index)
+	})
+}
+
+// SponsorableTransaction is a vault transaction that can be wrapped by Sponsored
+type SponsorableTransaction interface {
+	types.Transaction
+	chain.AccountTransaction
+	Fee(p types.Process, loader types.LoaderWrapper) *amount.Amount
+}
+
+// Transaction returns the inner transaction after checking it is a vault transaction of the sender
+func (tx *Sponsored) Transaction(sp *Vault) (SponsorableTransaction, error) {
+	if uint8(tx.TxType>>8) != sp.pid {
+		return nil, ErrInvalidSponsoredTransaction
+	}
+	fc := encoding.Factory("transaction")
+	t, err := fc.Create(tx.TxType)
+	if err != nil {
+		return nil, err
+	}
+	if err := encoding.Unmarshal(tx.TxData, &t); err != nil {
+		return nil, err
+	}
+	itx, is := t.(SponsorableTransaction)
+	if !is {
+		return nil, ErrInvalidSponsoredTransaction
+	}
+	if _, is := itx.(*Sponsored); is {
+		return nil, ErrInvalidSponsoredTransaction
+	}
+	if itx.From() != tx.From() || itx.Seq() != tx.Seq() {
+		return nil, ErrInvalidSponsoredTransaction
+	}
+	return itx, nil
+}
+
+// MarshalJSON is a marshaler function
+func (tx *Sponsored) MarshalJSON() ([]byte, error) {
+	var buffer bytes.Buffer
+	buffer.WriteString(`{`)
+	buffer.WriteString(`"timestamp":`)
+	if bs, err := json.Marshal(tx.Timestamp_); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"seq":`)
+	if bs, err := json.Marshal(tx.Seq_); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"from":`)
+	if bs, err := tx.From_.MarshalJSON(); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"payer":`)
+	if bs, err := tx.Payer.MarshalJSON(); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"tx_type":`)
+	if bs, err := json.Marshal(tx.TxType); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"tx_data":`)
+	buffer.WriteString(`"`)
+	buffer.WriteString(hex.EncodeToString(tx.TxData))
+	buffer.WriteString(`"`)
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"payer_signer_count":`)
+	if bs, err := json.Marshal(tx.PayerSignerCount); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`}`)
+	return buffer.Bytes(), nil
+}
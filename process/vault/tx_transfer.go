@@ -64,6 +64,9 @@ func (tx *Transfer) Validate(p types.Process, loader types.LoaderWrapper, signer
 		return err
 	}
 
+	if err := sp.CheckSpending(loader, tx.From(), []common.Address{tx.To}, tx.Amount); err != nil {
+		return err
+	}
 	if err := sp.CheckFeePayableWith(p, loader, tx, tx.Amount); err != nil {
 		return err
 	}
@@ -0,0 +1,182 @@
+package vault
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/fletaio/fleta/common"
+	"github.com/fletaio/fleta/common/amount"
+	"github.com/fletaio/fleta/core/types"
+)
+
+// TransferLocked sends the amount that is unlocked at the unlock height
+// when VestingCount is greater than 1, the amount is unlocked linearly by VestingCount parts at every VestingInterval from the unlock height
+type TransferLocked struct {
+	Timestamp_      uint64
+	Seq_            uint64
+	From_           common.Address
+	To              common.Address
+	Amount          *amount.Amount
+	UnlockHeight    uint32
+	VestingCount    uint32
+	VestingInterval uint32
+}
+
+// Timestamp returns the timestamp of the transaction
+func (tx *TransferLocked) Timestamp() uint64 {
+	return tx.Timestamp_
+}
+
+// Seq returns the sequence of the transaction
+func (tx *TransferLocked) Seq() uint64 {
+	return tx.Seq_
+}
+
+// From returns the from address of the transaction
+func (tx *TransferLocked) From() common.Address {
+	return tx.From_
+}
+
+// Fee returns the fee of the transaction
+func (tx *TransferLocked) Fee(p types.Process, loader types.LoaderWrapper) *amount.Amount {
+	sp := p.(*Vault)
+	return sp.GetDefaultFee(loader).MulC(int64(tx.VestingCount))
+}
+
+// Validate validates signatures of the transaction
+func (tx *TransferLocked) Validate(p types.Process, loader types.LoaderWrapper, signers []common.PublicHash) error {
+	sp := p.(*Vault)
+
+	if tx.Amount.Less(amount.COIN.DivC(10)) {
+		return types.ErrDustAmount
+	}
+	if tx.UnlockHeight <= loader.TargetHeight() {
+		return ErrInvalidUnlockHeight
+	}
+	if tx.VestingCount < 1 || tx.VestingCount > 120 {
+		return ErrInvalidVestingCount
+	}
+	if tx.VestingCount > 1 {
+		if tx.VestingInterval < 1 {
+			return ErrInvalidVestingInterval
+		}
+		if uint64(tx.UnlockHeight)+uint64(tx.VestingCount-1)*uint64(tx.VestingInterval) > uint64(^uint32(0)) {
+			return ErrInvalidVestingInterval
+		}
+		if tx.Amount.DivC(int64(tx.VestingCount)).IsZero() {
+			return ErrInvalidVestingCount
+		}
+	}
+	if tx.Seq() <= loader.Seq(tx.From()) {
+		return types.ErrInvalidSequence
+	}
+
+	if has, err := loader.HasAccount(tx.To); err != nil {
+		return err
+	} else if !has {
+		return types.ErrNotExistAccount
+	}
+
+	fromAcc, err := loader.Account(tx.From())
+	if err != nil {
+		return err
+	}
+	if err := fromAcc.Validate(loader, signers); err != nil {
+		return err
+	}
+
+	if err := sp.CheckSpending(loader, tx.From(), []common.Address{tx.To}, tx.Amount); err != nil {
+		return err
+	}
+	if err := sp.CheckFeePayableWith(p, loader, tx, tx.Amount); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Execute updates the context by the transaction
+func (tx *TransferLocked) Execute(p types.Process, ctw *types.ContextWrapper, index uint16) error {
+	sp := p.(*Vault)
+
+	return sp.WithFee(p, ctw, tx, func() error {
+		if err := sp.SubBalance(ctw, tx.From(), tx.Amount); err != nil {
+			return err
+		}
+		part := tx.Amount.DivC(int64(tx.VestingCount))
+		remain := tx.Amount.Clone()
+		for i := uint32(0); i < tx.VestingCount; i++ {
+			am := part
+			if i == tx.VestingCount-1 {
+				am = remain
+			}
+			if err := sp.AddLockedBalance(ctw, tx.To, tx.UnlockHeight+i*tx.VestingInterval, am); err != nil {
+				return err
+			}
+			remain = remain.Sub(am)
+		}
+		return nil
+	})
+}
+
+// MarshalJSON is a marshaler function
+func (tx *TransferLocked) MarshalJSON() ([]byte, error) {
+	var buffer bytes.Buffer
+	buffer.WriteString(`{`)
+	buffer.WriteString(`"timestamp":`)
+	if bs, err := json.Marshal(tx.Timestamp_); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"seq":`)
+	if bs, err := json.Marshal(tx.Seq_); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"from":`)
+	if bs, err := tx.From_.MarshalJSON(); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"to":`)
+	if bs, err := tx.To.MarshalJSON(); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"amount":`)
+	if bs, err := tx.Amount.MarshalJSON(); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"unlock_height":`)
+	if bs, err := json.Marshal(tx.UnlockHeight); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"vesting_count":`)
+	if bs, err := json.Marshal(tx.VestingCount); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"vesting_interval":`)
+	if bs, err := json.Marshal(tx.VestingInterval); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`}`)
+	return buffer.Bytes(), nil
+}
@@ -68,6 +68,9 @@ func (tx *TransferWithTag) Validate(p types.Process, loader types.LoaderWrapper,
 		return err
 	}
 
+	if err := sp.CheckSpending(loader, tx.From(), []common.Address{tx.To}, tx.Amount); err != nil {
+		return err
+	}
 	if err := sp.CheckFeePayableWith(p, loader, tx, tx.Amount); err != nil {
 		return err
 	}
@@ -37,6 +37,21 @@ func (tx *UpdateDefaultFee) From() common.Address {
 func (tx *UpdateDefaultFee) Validate(p types.Process, loader types.LoaderWrapper, signers []common.PublicHash) error {
 	sp := p.(*Vault)
 
+	if sp.admin.IsGoverned() {
+		return admin.ErrGovernedTransaction
+	}
+
+	if tx.Seq() <= loader.Seq(tx.From()) {
+		return types.ErrInvalidSequence
+	}
+	return tx.ValidatePayload(p, loader, signers)
+}
+
+// ValidatePayload validates the transaction except the sequence
+// the governance process validates proposals by it when proposed and activated
+func (tx *UpdateDefaultFee) ValidatePayload(p types.Process, loader types.LoaderWrapper, signers []common.PublicHash) error {
+	sp := p.(*Vault)
+
 	if tx.DefaultFee == nil {
 		return ErrInvalidDefaultFee
 	}
@@ -45,12 +60,11 @@ func (tx *UpdateDefaultFee) Validate(p types.Process, loader types.LoaderWrapper
 			return ErrInvalidDefaultFee
 		}
 	}
-	if tx.From() != sp.admin.AdminAddress(loader, p.Name()) {
+	if has, err := sp.admin.HasRole(loader, p.Name(), admin.RolePolicy, tx.From()); err != nil {
+		return err
+	} else if !has {
 		return admin.ErrUnauthorizedTransaction
 	}
-	if tx.Seq() <= loader.Seq(tx.From()) {
-		return types.ErrInvalidSequence
-	}
 
 	fromAcc, err := loader.Account(tx.From())
 	if err != nil {
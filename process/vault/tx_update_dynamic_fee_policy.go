@@ -0,0 +1,129 @@
+package vault
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/fletaio/fleta/common"
+	"github.com/fletaio/fleta/common/amount"
+	"github.com/fletaio/fleta/core/types"
+	"github.com/fletaio/fleta/process/admin"
+)
+
+// UpdateDynamicFeePolicy is used to enable or disable the congestion based base fee
+// nil Policy disables it and the default fee is used again
+type UpdateDynamicFeePolicy struct {
+	Timestamp_ uint64
+	Seq_       uint64
+	From_      common.Address
+	Policy     *DynamicFeePolicy
+}
+
+// Timestamp returns the timestamp of the transaction
+func (tx *UpdateDynamicFeePolicy) Timestamp() uint64 {
+	return tx.Timestamp_
+}
+
+// Seq returns the sequence of the transaction
+func (tx *UpdateDynamicFeePolicy) Seq() uint64 {
+	return tx.Seq_
+}
+
+// From returns the from address of the transaction
+func (tx *UpdateDynamicFeePolicy) From() common.Address {
+	return tx.From_
+}
+
+// Validate validates signatures of the transaction
+func (tx *UpdateDynamicFeePolicy) Validate(p types.Process, loader types.LoaderWrapper, signers []common.PublicHash) error {
+	sp := p.(*Vault)
+
+	if sp.admin.IsGoverned() {
+		return admin.ErrGovernedTransaction
+	}
+
+	if tx.Seq() <= loader.Seq(tx.From()) {
+		return types.ErrInvalidSequence
+	}
+	return tx.ValidatePayload(p, loader, signers)
+}
+
+// ValidatePayload validates the transaction except the sequence
+// the governance process validates proposals by it when proposed and activated
+func (tx *UpdateDynamicFeePolicy) ValidatePayload(p types.Process, loader types.LoaderWrapper, signers []common.PublicHash) error {
+	sp := p.(*Vault)
+
+	if tx.Policy != nil {
+		pc := tx.Policy
+		if pc.MinFee == nil || pc.MaxFee == nil {
+			return ErrInvalidDynamicFeePolicy
+		}
+		if pc.MinFee.Less(amount.COIN.DivC(100000000)) || pc.MaxFee.Less(pc.MinFee) {
+			return ErrInvalidDynamicFeePolicy
+		}
+		if pc.TargetTransactions == 0 || pc.MaxTransactionsPerBlock < pc.TargetTransactions {
+			return ErrInvalidDynamicFeePolicy
+		}
+		if pc.AdjustmentDenominator == 0 {
+			return ErrInvalidDynamicFeePolicy
+		}
+	}
+	if has, err := sp.admin.HasRole(loader, p.Name(), admin.RolePolicy, tx.From()); err != nil {
+		return err
+	} else if !has {
+		return admin.ErrUnauthorizedTransaction
+	}
+
+	fromAcc, err := loader.Account(tx.From())
+	if err != nil {
+		return err
+	}
+	if err := fromAcc.Validate(loader, signers); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Execute updates the context by the transaction
+func (tx *UpdateDynamicFeePolicy) Execute(p types.Process, ctw *types.ContextWrapper, index uint16) error {
+	sp := p.(*Vault)
+
+	return sp.setDynamicFeePolicy(ctw, tx.Policy)
+}
+
+// MarshalJSON is a marshaler function
+func (tx *UpdateDynamicFeePolicy) MarshalJSON() ([]byte, error) {
+	var buffer bytes.Buffer
+	buffer.WriteString(`{`)
+	buffer.WriteString(`"timestamp":`)
+	if bs, err := json.Marshal(tx.Timestamp_); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"seq":`)
+	if bs, err := json.Marshal(tx.Seq_); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"from":`)
+	if bs, err := tx.From_.MarshalJSON(); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"policy":`)
+	if tx.Policy == nil {
+		buffer.WriteString(`null`)
+	} else if bs, err := tx.Policy.MarshalJSON(); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`}`)
+	return buffer.Bytes(), nil
+}
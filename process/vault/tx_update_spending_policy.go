@@ -0,0 +1,150 @@
+package vault
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/fletaio/fleta/common"
+	"github.com/fletaio/fleta/common/amount"
+	"github.com/fletaio/fleta/core/types"
+)
+
+// UpdateSpendingPolicy sets the spending policy of the account
+// the first policy is applied immediately and later changes are applied after SpendingPolicyDelay
+// zero Limit with empty AllowList removes the policy
+type UpdateSpendingPolicy struct {
+	Timestamp_ uint64
+	Seq_       uint64
+	From_      common.Address
+	Limit      *amount.Amount
+	Window     uint32
+	AllowList  []common.Address
+}
+
+// Timestamp returns the timestamp of the transaction
+func (tx *UpdateSpendingPolicy) Timestamp() uint64 {
+	return tx.Timestamp_
+}
+
+// Seq returns the sequence of the transaction
+func (tx *UpdateSpendingPolicy) Seq() uint64 {
+	return tx.Seq_
+}
+
+// From returns the from address of the transaction
+func (tx *UpdateSpendingPolicy) From() common.Address {
+	return tx.From_
+}
+
+// Fee returns the fee of the transaction
+func (tx *UpdateSpendingPolicy) Fee(p types.Process, loader types.LoaderWrapper) *amount.Amount {
+	sp := p.(*Vault)
+	return sp.GetDefaultFee(loader)
+}
+
+// Validate validates signatures of the transaction
+func (tx *UpdateSpendingPolicy) Validate(p types.Process, loader types.LoaderWrapper, signers []common.PublicHash) error {
+	sp := p.(*Vault)
+
+	if tx.Limit.Less(amount.NewCoinAmount(0, 0)) {
+		return ErrMinusInput
+	}
+	if !tx.Limit.IsZero() && tx.Window < 1 {
+		return ErrInvalidSpendingPolicy
+	}
+	if len(tx.AllowList) > 100 {
+		return ErrInvalidSpendingPolicy
+	}
+	addrMap := map[common.Address]bool{}
+	for _, addr := range tx.AllowList {
+		addrMap[addr] = true
+	}
+	if len(addrMap) != len(tx.AllowList) {
+		return ErrInvalidSpendingPolicy
+	}
+	if tx.Seq() <= loader.Seq(tx.From()) {
+		return types.ErrInvalidSequence
+	}
+
+	fromAcc, err := loader.Account(tx.From())
+	if err != nil {
+		return err
+	}
+	if err := fromAcc.Validate(loader, signers); err != nil {
+		return err
+	}
+
+	if err := sp.CheckFeePayable(p, loader, tx); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Execute updates the context by the transaction
+func (tx *UpdateSpendingPolicy) Execute(p types.Process, ctw *types.ContextWrapper, index uint16) error {
+	sp := p.(*Vault)
+
+	return sp.WithFee(p, ctw, tx, func() error {
+		return sp.updateSpendingPolicy(ctw, tx.From(), &SpendingPolicy{
+			Limit:     tx.Limit,
+			Window:    tx.Window,
+			AllowList: tx.AllowList,
+		})
+	})
+}
+
+// MarshalJSON is a marshaler function
+func (tx *UpdateSpendingPolicy) MarshalJSON() ([]byte, error) {
+	var buffer bytes.Buffer
+	buffer.WriteString(`{`)
+	buffer.WriteString(`"timestamp":`)
+	if bs, err := json.Marshal(tx.Timestamp_); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"seq":`)
+	if bs, err := json.Marshal(tx.Seq_); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"from":`)
+	if bs, err := tx.From_.MarshalJSON(); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"limit":`)
+	if bs, err := tx.Limit.MarshalJSON(); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"window":`)
+	if bs, err := json.Marshal(tx.Window); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"allow_list":`)
+	buffer.WriteString(`[`)
+	for i, addr := range tx.AllowList {
+		if i > 0 {
+			buffer.WriteString(`,`)
+		}
+		if bs, err := addr.MarshalJSON(); err != nil {
+			return nil, err
+		} else {
+			buffer.Write(bs)
+		}
+	}
+	buffer.WriteString(`]`)
+	buffer.WriteString(`}`)
+	return buffer.Bytes(), nil
+}
@@ -7,16 +7,26 @@ import (
 
 // tags
 var (
-	tagBalance              = []byte{1, 1}
-	tagLockedBalance        = []byte{2, 1}
-	tagLockedBalanceNumber  = []byte{2, 2}
-	tagLockedBalanceReverse = []byte{2, 3}
-	tagLockedBalanceCount   = []byte{2, 4}
-	tagLockedBalanceSum     = []byte{2, 5}
-	tagCollectedFee         = []byte{3, 1}
-	tagPolicy               = []byte{4, 0}
-	tagDefaultFee           = []byte{4, 1}
-	tagDefaultFeeIsZero     = []byte{4, 2}
+	tagBalance               = []byte{1, 1}
+	tagLockedBalance         = []byte{2, 1}
+	tagLockedBalanceNumber   = []byte{2, 2}
+	tagLockedBalanceReverse  = []byte{2, 3}
+	tagLockedBalanceCount    = []byte{2, 4}
+	tagLockedBalanceSum      = []byte{2, 5}
+	tagLockedBalanceHeights  = []byte{2, 6}
+	tagLockedBalanceBackfill = []byte{2, 7}
+	tagCollectedFee          = []byte{3, 1}
+	tagRecoveryGuardian      = []byte{5, 1}
+	tagPendingRecovery       = []byte{5, 2}
+	tagSpendingPolicy        = []byte{6, 1}
+	tagPendingSpending       = []byte{6, 2}
+	tagSpentAmount           = []byte{6, 3}
+	tagHTLC                  = []byte{7, 1}
+	tagPolicy                = []byte{4, 0}
+	tagDefaultFee            = []byte{4, 1}
+	tagDefaultFeeIsZero      = []byte{4, 2}
+	tagDynamicFeePolicy      = []byte{4, 3}
+	tagBaseFee               = []byte{4, 4}
 )
 
 func toLockedBalanceKey(height uint32, addr common.Address) []byte {
@@ -49,3 +59,11 @@ func toLockedBalanceCountKey(height uint32) []byte {
 	binutil.BigEndian.PutUint32(bs[2:], height)
 	return bs
 }
+
+func toHTLCKey(height uint32, index uint16) []byte {
+	bs := make([]byte, 8)
+	copy(bs, tagHTLC)
+	binutil.BigEndian.PutUint32(bs[2:], height)
+	binutil.BigEndian.PutUint16(bs[6:], index)
+	return bs
+}
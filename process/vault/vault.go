@@ -1,6 +1,8 @@
 package vault
 
 import (
+	"sync"
+
 	"github.com/fletaio/fleta/common"
 	"github.com/fletaio/fleta/common/amount"
 	"github.com/fletaio/fleta/core/types"
@@ -16,6 +18,8 @@ type Vault struct {
 	pm    types.ProcessManager
 	cn    types.Provider
 	admin *admin.Admin
+
+	sponsorMap sync.Map
 }
 
 // NewVault returns a Vault
@@ -56,6 +60,22 @@ func (p *Vault) Init(reg *types.Register, pm types.ProcessManager, cn types.Prov
 	reg.RegisterTransaction(10, &UpdatePolicy{})
 	reg.RegisterTransaction(11, &ChangeSingleKey{})
 	reg.RegisterTransaction(12, &UpdateDefaultFee{})
+	reg.RegisterTransaction(13, &TransferLocked{})
+	reg.RegisterTransaction(14, &MultiTransfer{})
+	reg.RegisterTransaction(15, &ChangeMultiKey{})
+	reg.RegisterTransaction(16, &SetRecoveryGuardian{})
+	reg.RegisterTransaction(17, &RequestRecovery{})
+	reg.RegisterTransaction(18, &CancelRecovery{})
+	reg.RegisterTransaction(19, &ExecuteRecovery{})
+	reg.RegisterTransaction(20, &UpdateSpendingPolicy{})
+	reg.RegisterTransaction(21, &LockHTLC{})
+	reg.RegisterTransaction(22, &ClaimHTLC{})
+	reg.RegisterTransaction(23, &RefundHTLC{})
+	reg.RegisterTransaction(24, &Sponsored{})
+	reg.RegisterTransaction(25, &UpdateDynamicFeePolicy{})
+	reg.RegisterEvent(1, &HTLCLockedEvent{})
+	reg.RegisterEvent(2, &HTLCClaimedEvent{})
+	reg.RegisterEvent(3, &HTLCRefundedEvent{})
 
 	if vp, err := pm.ProcessByName("fleta.admin"); err != nil {
 		return err
@@ -88,6 +108,120 @@ func (p *Vault) Init(reg *types.Register, pm types.ProcessManager, cn types.Prov
 			loader := cn.NewLoaderWrapper(p.ID())
 			return p.Balance(loader, addr), nil
 		})
+		s.Set("lockedBalances", func(ID interface{}, arg *apiserver.Argument) (interface{}, error) {
+			if arg.Len() != 1 {
+				return nil, apiserver.ErrInvalidArgument
+			}
+			arg0, err := arg.String(0)
+			if err != nil {
+				return nil, err
+			}
+			addr, err := common.ParseAddress(arg0)
+			if err != nil {
+				return nil, err
+			}
+			loader := cn.NewLoaderWrapper(p.ID())
+			heights := p.LockedBalanceHeights(loader, addr)
+			list := make([]map[string]interface{}, 0, len(heights))
+			for _, UnlockedHeight := range heights {
+				list = append(list, map[string]interface{}{
+					"height": UnlockedHeight,
+					"amount": p.LockedBalance(loader, addr, UnlockedHeight),
+				})
+			}
+			return map[string]interface{}{
+				"total":   p.TotalLockedBalanceByAddress(loader, addr),
+				"unlocks": list,
+			}, nil
+		})
+		s.Set("recovery", func(ID interface{}, arg *apiserver.Argument) (interface{}, error) {
+			if arg.Len() != 1 {
+				return nil, apiserver.ErrInvalidArgument
+			}
+			arg0, err := arg.String(0)
+			if err != nil {
+				return nil, err
+			}
+			addr, err := common.ParseAddress(arg0)
+			if err != nil {
+				return nil, err
+			}
+			loader := cn.NewLoaderWrapper(p.ID())
+			rg, err := p.RecoveryGuardian(loader, addr)
+			if err != nil {
+				return nil, err
+			}
+			mp := map[string]interface{}{
+				"guardian": rg,
+			}
+			if pr, err := p.PendingRecovery(loader, addr); err != nil {
+				if err != ErrNotExistPendingRecovery {
+					return nil, err
+				}
+			} else {
+				mp["pending"] = pr
+			}
+			return mp, nil
+		})
+		s.Set("spendingPolicy", func(ID interface{}, arg *apiserver.Argument) (interface{}, error) {
+			if arg.Len() != 1 {
+				return nil, apiserver.ErrInvalidArgument
+			}
+			arg0, err := arg.String(0)
+			if err != nil {
+				return nil, err
+			}
+			addr, err := common.ParseAddress(arg0)
+			if err != nil {
+				return nil, err
+			}
+			loader := cn.NewLoaderWrapper(p.ID())
+			mp := map[string]interface{}{}
+			if policy, err := p.SpendingPolicy(loader, addr); err != nil {
+				if err != ErrNotExistSpendingPolicy {
+					return nil, err
+				}
+			} else {
+				mp["policy"] = policy
+				mp["spent"] = p.SpentAmount(loader, addr, policy.Window)
+			}
+			if pp, err := p.PendingSpendingPolicy(loader, addr); err != nil {
+				if err != ErrNotExistSpendingPolicy {
+					return nil, err
+				}
+			} else if pp.ActivateHeight > loader.TargetHeight() {
+				mp["pending"] = pp
+			}
+			return mp, nil
+		})
+		s.Set("htlc", func(ID interface{}, arg *apiserver.Argument) (interface{}, error) {
+			if arg.Len() != 1 {
+				return nil, apiserver.ErrInvalidArgument
+			}
+			ContractID, err := arg.String(0)
+			if err != nil {
+				return nil, err
+			}
+			loader := cn.NewLoaderWrapper(p.ID())
+			ht, err := p.HTLC(loader, ContractID)
+			if err != nil {
+				return nil, err
+			}
+			return ht, nil
+		})
+		s.Set("baseFee", func(ID interface{}, arg *apiserver.Argument) (interface{}, error) {
+			loader := cn.NewLoaderWrapper(p.ID())
+			mp := map[string]interface{}{
+				"base_fee": p.GetDefaultFee(loader),
+			}
+			if pc, err := p.DynamicFeePolicy(loader); err == nil {
+				mp["dynamic"] = true
+				mp["policy"] = pc
+			} else {
+				mp["dynamic"] = false
+			}
+			return mp, nil
+		})
 		s.Set("collectedFee", func(ID interface{}, arg *apiserver.Argument) (interface{}, error) {
 			loader := cn.NewLoaderWrapper(p.ID())
 			return p.CollectedFee(loader), nil
@@ -124,7 +258,9 @@ func (p *Vault) SetDefaultFee(ctw *types.ContextWrapper, defaultFee *amount.Amou
 
 // OnLoadChain called when the chain loaded
 func (p *Vault) OnLoadChain(loader types.LoaderWrapper) error {
-	p.admin.AdminAddress(loader, p.Name())
+	if _, err := p.admin.AdminAddress(loader, p.Name()); err != nil {
+		return err
+	}
 	if bs := loader.ProcessData(tagPolicy); len(bs) == 0 {
 		return ErrPolicyShouldBeSetupInApplication
 	}
@@ -133,11 +269,16 @@ func (p *Vault) OnLoadChain(loader types.LoaderWrapper) error {
 
 // BeforeExecuteTransactions called before processes transactions of the block
 func (p *Vault) BeforeExecuteTransactions(ctw *types.ContextWrapper) error {
+	p.backfillLockedBalanceHeights(ctw)
 	return nil
 }
 
 // AfterExecuteTransactions called after processes transactions of the block
 func (p *Vault) AfterExecuteTransactions(b *types.Block, ctw *types.ContextWrapper) error {
+	if err := p.updateBaseFee(ctw, len(b.Transactions)); err != nil {
+		return err
+	}
+
 	LockedBalanceMap, err := p.flushLockedBalanceMap(ctw, b.Header.Height)
 	if err != nil {
 		return err
@@ -147,12 +288,15 @@ func (p *Vault) AfterExecuteTransactions(b *types.Block, ctw *types.ContextWrapp
 		if has, err := ctw.HasAccount(addr); err != nil {
 			if err == types.ErrDeletedAccount {
 				ctw.SetAccountData(addr, tagLockedBalanceSum, nil)
+				ctw.SetAccountData(addr, tagLockedBalanceHeights, nil)
 			} else {
 				return err
 			}
 		} else if !has {
 			ctw.SetAccountData(addr, tagLockedBalanceSum, nil)
+			ctw.SetAccountData(addr, tagLockedBalanceHeights, nil)
 		} else {
+			p.removeLockedBalanceHeight(ctw, addr, b.Header.Height)
 			if err := p.AddBalance(ctw, addr, am); err != nil {
 				return err
 			}
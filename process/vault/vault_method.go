@@ -5,6 +5,7 @@ import (
 	"github.com/fletaio/fleta/common/amount"
 	"github.com/fletaio/fleta/common/binutil"
 	"github.com/fletaio/fleta/core/types"
+	"github.com/fletaio/fleta/encoding"
 )
 
 // Balance returns balance of the account of the address
@@ -41,6 +42,9 @@ func (p *Vault) SubBalance(ctw *types.ContextWrapper, addr common.Address, am *a
 	if sum.Less(am) {
 		return ErrMinusBalance
 	}
+	if err := p.addSpending(ctw, addr, am); err != nil {
+		return err
+	}
 	//log.Println("SubBalance", ctw.TargetHeight(), addr.String(), am.String(), p.Balance(ctw, addr).Sub(am).String())
 
 	sum = sum.Sub(am)
@@ -99,12 +103,101 @@ func (p *Vault) AddLockedBalance(ctw *types.ContextWrapper, addr common.Address,
 		ctw.SetProcessData(toLockedBalanceReverseKey(UnlockedHeight, Count), addr[:])
 		Count++
 		ctw.SetProcessData(toLockedBalanceCountKey(UnlockedHeight), binutil.LittleEndian.Uint32ToBytes(Count))
+		p.addLockedBalanceHeight(ctw, addr, UnlockedHeight)
 	}
 	ctw.SetProcessData(toLockedBalanceKey(UnlockedHeight, addr), p.LockedBalance(ctw, addr, UnlockedHeight).Add(am).Bytes())
 	ctw.SetAccountData(addr, tagLockedBalanceSum, p.TotalLockedBalanceByAddress(ctw, addr).Add(am).Bytes())
 	return nil
 }
 
+// LockedBalanceHeights returns unlock heights of locked balances of the account of the address in ascending order
+func (p *Vault) LockedBalanceHeights(loader types.Loader, addr common.Address) []uint32 {
+	lw := types.NewLoaderWrapper(p.pid, loader)
+
+	bs := lw.AccountData(addr, tagLockedBalanceHeights)
+	heights := make([]uint32, 0, len(bs)/4)
+	for i := 0; i+4 <= len(bs); i += 4 {
+		heights = append(heights, binutil.BigEndian.Uint32(bs[i:]))
+	}
+	return heights
+}
+
+func (p *Vault) addLockedBalanceHeight(ctw *types.ContextWrapper, addr common.Address, UnlockedHeight uint32) {
+	heights := p.LockedBalanceHeights(ctw, addr)
+	bs := make([]byte, 0, (len(heights)+1)*4)
+	isAdded := false
+	for _, h := range heights {
+		if h == UnlockedHeight {
+			return
+		}
+		if !isAdded && UnlockedHeight < h {
+			bs = append(bs, binutil.BigEndian.Uint32ToBytes(UnlockedHeight)...)
+			isAdded = true
+		}
+		bs = append(bs, binutil.BigEndian.Uint32ToBytes(h)...)
+	}
+	if !isAdded {
+		bs = append(bs, binutil.BigEndian.Uint32ToBytes(UnlockedHeight)...)
+	}
+	ctw.SetAccountData(addr, tagLockedBalanceHeights, bs)
+}
+
+func (p *Vault) removeLockedBalanceHeight(ctw *types.ContextWrapper, addr common.Address, UnlockedHeight uint32) {
+	heights := p.LockedBalanceHeights(ctw, addr)
+	bs := make([]byte, 0, len(heights)*4)
+	for _, h := range heights {
+		if h != UnlockedHeight {
+			bs = append(bs, binutil.BigEndian.Uint32ToBytes(h)...)
+		}
+	}
+	if len(bs) > 0 {
+		ctw.SetAccountData(addr, tagLockedBalanceHeights, bs)
+	} else {
+		ctw.SetAccountData(addr, tagLockedBalanceHeights, nil)
+	}
+}
+
+// LockedBalanceBackfillBlocks covers the longest unlock period of locked balances queued before the unlock height index
+const LockedBalanceBackfillBlocks = 2592000
+
+// LockedBalanceBackfillBatch is the number of unlock heights scanned by a block to backfill the unlock height index
+const LockedBalanceBackfillBatch = 10000
+
+// backfillLockedBalanceHeights adds locked balances queued before the unlock height index to the index
+// it scans LockedBalanceBackfillBatch unlock heights per block from the cursor until LockedBalanceBackfillBlocks after the first block executed with the index
+// the cursor stores the next height to scan and the last height to scan
+func (p *Vault) backfillLockedBalanceHeights(ctw *types.ContextWrapper) {
+	From := ctw.TargetHeight()
+	To := ctw.TargetHeight() + LockedBalanceBackfillBlocks
+	if bs := ctw.ProcessData(tagLockedBalanceBackfill); len(bs) == 8 {
+		From = binutil.LittleEndian.Uint32(bs[:4])
+		To = binutil.LittleEndian.Uint32(bs[4:])
+		if From > To {
+			return
+		}
+	}
+	// locked balances under the target height are already unlocked
+	if From < ctw.TargetHeight() {
+		From = ctw.TargetHeight()
+	}
+	End := From + LockedBalanceBackfillBatch - 1
+	if End > To {
+		End = To
+	}
+	ctw.SetProcessData(tagLockedBalanceBackfill, append(binutil.LittleEndian.Uint32ToBytes(End+1), binutil.LittleEndian.Uint32ToBytes(To)...))
+
+	for UnlockedHeight := From; UnlockedHeight <= End; UnlockedHeight++ {
+		if bs := ctw.ProcessData(toLockedBalanceCountKey(UnlockedHeight)); len(bs) > 0 {
+			Count := binutil.LittleEndian.Uint32(bs)
+			for i := uint32(0); i < Count; i++ {
+				var addr common.Address
+				copy(addr[:], ctw.ProcessData(toLockedBalanceReverseKey(UnlockedHeight, i)))
+				p.addLockedBalanceHeight(ctw, addr, UnlockedHeight)
+			}
+		}
+	}
+}
+
 func (p *Vault) flushLockedBalanceMap(ctw *types.ContextWrapper, UnlockedHeight uint32) (map[common.Address]*amount.Amount, error) {
 	LockedBalanceMap := map[common.Address]*amount.Amount{}
 	if bs := ctw.ProcessData(toLockedBalanceCountKey(UnlockedHeight)); len(bs) > 0 {
@@ -129,6 +222,8 @@ func (p *Vault) CheckFeePayable(tp types.Process, loader types.Loader, tx FeeTra
 }
 
 // CheckFeePayableWith returns tx fee and amount can be paid or not
+// when the transaction is sponsored, the fee is checked from the payer and the amount is checked from the sender
+// the outflows are also checked by the spending limits of the payer and the sender
 func (p *Vault) CheckFeePayableWith(tp types.Process, loader types.Loader, tx FeeTransaction, am *amount.Amount) error {
 	lw := types.NewLoaderWrapper(p.pid, loader)
 
@@ -139,6 +234,28 @@ func (p *Vault) CheckFeePayableWith(tp types.Process, loader types.Loader, tx Fe
 	}
 
 	fee := tx.Fee(tp, lw)
+	if Payer := p.FeePayer(tx); Payer != tx.From() {
+		if has, err := lw.HasAccount(Payer); err != nil {
+			return err
+		} else if !has {
+			return types.ErrNotExistAccount
+		}
+		if p.Balance(lw, Payer).Less(fee) {
+			return ErrInsufficientFee
+		}
+		if err := p.CheckSpending(lw, Payer, nil, fee); err != nil {
+			return err
+		}
+		if am != nil {
+			if p.Balance(lw, tx.From()).Less(am) {
+				return ErrInsufficientBalance
+			}
+			if err := p.CheckSpending(lw, tx.From(), nil, am); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
 	if am != nil {
 		am = am.Add(fee)
 	} else {
@@ -149,6 +266,9 @@ func (p *Vault) CheckFeePayableWith(tp types.Process, loader types.Loader, tx Fe
 	if b.Less(am) {
 		return ErrInsufficientFee
 	}
+	if err := p.CheckSpending(lw, tx.From(), nil, am); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -157,7 +277,7 @@ func (p *Vault) WithFee(tp types.Process, ctw *types.ContextWrapper, tx FeeTrans
 	ctw = types.SwitchContextWrapper(p.pid, ctw)
 
 	fee := tx.Fee(tp, ctw)
-	if err := p.SubBalance(ctw, tx.From(), fee); err != nil {
+	if err := p.SubBalance(ctw, p.FeePayer(tx), fee); err != nil {
 		return err
 	}
 	ctw.SetProcessData(tagCollectedFee, p.CollectedFee(ctw).Add(fee).Bytes())
@@ -217,9 +337,17 @@ func (p *Vault) SubCollectedFee(ctw *types.ContextWrapper, am *amount.Amount) er
 }
 
 // GetDefaultFee returns a default fee of the chain
+// it returns the base fee when the dynamic fee policy is enabled
 func (p *Vault) GetDefaultFee(loader types.LoaderWrapper) *amount.Amount {
 	lw := types.NewLoaderWrapper(p.pid, loader)
 
+	if bs := lw.ProcessData(tagBaseFee); len(bs) > 0 {
+		return amount.NewAmountFromBytes(bs)
+	}
+	return p.staticDefaultFee(lw)
+}
+
+func (p *Vault) staticDefaultFee(lw types.LoaderWrapper) *amount.Amount {
 	bs := lw.ProcessData(tagDefaultFee)
 	if len(bs) == 0 {
 		bs := lw.ProcessData(tagDefaultFeeIsZero)
@@ -231,3 +359,71 @@ func (p *Vault) GetDefaultFee(loader types.LoaderWrapper) *amount.Amount {
 	}
 	return amount.NewAmountFromBytes(bs)
 }
+
+// DynamicFeePolicy returns the dynamic fee policy
+func (p *Vault) DynamicFeePolicy(loader types.LoaderWrapper) (*DynamicFeePolicy, error) {
+	lw := types.NewLoaderWrapper(p.pid, loader)
+
+	bs := lw.ProcessData(tagDynamicFeePolicy)
+	if len(bs) == 0 {
+		return nil, ErrNotExistDynamicFeePolicy
+	}
+	pc := &DynamicFeePolicy{}
+	if err := encoding.Unmarshal(bs, &pc); err != nil {
+		return nil, err
+	}
+	return pc, nil
+}
+
+func (p *Vault) setDynamicFeePolicy(ctw *types.ContextWrapper, pc *DynamicFeePolicy) error {
+	ctw = types.SwitchContextWrapper(p.pid, ctw)
+
+	if pc == nil {
+		ctw.SetProcessData(tagDynamicFeePolicy, nil)
+		ctw.SetProcessData(tagBaseFee, nil)
+		return nil
+	}
+	bs, err := encoding.Marshal(pc)
+	if err != nil {
+		return err
+	}
+	ctw.SetProcessData(tagDynamicFeePolicy, bs)
+	ctw.SetProcessData(tagBaseFee, clampFee(p.GetDefaultFee(ctw), pc).Bytes())
+	return nil
+}
+
+// updateBaseFee adjusts the base fee of the next block by the transaction count of the block
+func (p *Vault) updateBaseFee(ctw *types.ContextWrapper, TxCount int) error {
+	ctw = types.SwitchContextWrapper(p.pid, ctw)
+
+	if bs := ctw.ProcessData(tagDynamicFeePolicy); len(bs) == 0 {
+		return nil
+	}
+	pc, err := p.DynamicFeePolicy(ctw)
+	if err != nil {
+		return err
+	}
+	if TxCount > int(pc.MaxTransactionsPerBlock) {
+		TxCount = int(pc.MaxTransactionsPerBlock)
+	}
+	base := p.GetDefaultFee(ctw)
+	Target := int64(pc.TargetTransactions)
+	Denom := int64(pc.AdjustmentDenominator)
+	if Gap := int64(TxCount) - Target; Gap > 0 {
+		base = base.Add(base.MulC(Gap).DivC(Target * Denom))
+	} else if Gap < 0 {
+		base = base.Sub(base.MulC(-Gap).DivC(Target * Denom))
+	}
+	ctw.SetProcessData(tagBaseFee, clampFee(base, pc).Bytes())
+	return nil
+}
+
+func clampFee(fee *amount.Amount, pc *DynamicFeePolicy) *amount.Amount {
+	if fee.Less(pc.MinFee) {
+		return pc.MinFee.Clone()
+	}
+	if pc.MaxFee.Less(fee) {
+		return pc.MaxFee.Clone()
+	}
+	return fee
+}
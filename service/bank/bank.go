@@ -16,6 +16,7 @@ import (
 
 	"github.com/fletaio/fleta/core/chain"
 	"github.com/fletaio/fleta/process/formulator"
+	"github.com/fletaio/fleta/process/token"
 	"github.com/fletaio/fleta/process/vault"
 
 	"github.com/fletaio/fleta/common"
@@ -304,6 +305,85 @@ func (s *Bank) Init(pm types.ProcessManager, cn types.Provider) error {
 			}
 			return TxHash, nil
 		})
+		as.Set("sendToken", func(ID interface{}, arg *apiserver.Argument) (interface{}, error) {
+			if arg.Len() != 5 {
+				return nil, apiserver.ErrInvalidArgument
+			}
+			fromStr, err := arg.String(0)
+			if err != nil {
+				return nil, err
+			}
+			from, err := common.ParseAddress(fromStr)
+			if err != nil {
+				return nil, err
+			}
+			Symbol, err := arg.String(1)
+			if err != nil {
+				return nil, err
+			}
+			toStr, err := arg.String(2)
+			if err != nil {
+				return nil, err
+			}
+			to, err := common.ParseAddress(toStr)
+			if err != nil {
+				return nil, err
+			}
+			amStr, err := arg.String(3)
+			if err != nil {
+				return nil, err
+			}
+			am, err := amount.ParseAmount(amStr)
+			if err != nil {
+				return nil, err
+			}
+			Password, err := arg.String(4)
+			if err != nil {
+				return nil, err
+			}
+
+			name, err := s.NameByAddress(from)
+			if err != nil {
+				return nil, err
+			}
+
+			s.Lock()
+			Seq, has := s.seqMap[from]
+			ChainSeq := s.cn.Seq(from)
+			if !has || Seq < ChainSeq {
+				Seq = ChainSeq
+			}
+			Seq++
+			s.seqMap[from] = Seq
+			s.Unlock()
+
+			tx := &token.Transfer{
+				Timestamp_: uint64(time.Now().UnixNano()),
+				Seq_:       Seq,
+				From_:      from,
+				Symbol:     Symbol,
+				To:         to,
+				Amount:     am,
+			}
+			TxHash := chain.HashTransaction(s.cn.ChainID(), tx)
+			sig, err := s.Sign(name, Password, TxHash)
+			if err != nil {
+				s.Lock()
+				s.seqMap[from] = Seq - 1
+				s.Unlock()
+				return nil, err
+			}
+			if err := s.nd.AddTx(tx, []common.Signature{sig}); err != nil {
+				s.Lock()
+				s.seqMap[from] = Seq - 1
+				s.Unlock()
+				return nil, err
+			}
+			if err := s.addPending(tx); err != nil {
+				return nil, err
+			}
+			return TxHash, nil
+		})
 		as.Set("transaction", func(ID interface{}, arg *apiserver.Argument) (interface{}, error) {
 			if arg.Len() != 1 {
 				return nil, apiserver.ErrInvalidArgument
@@ -536,10 +616,200 @@ func (s *Bank) Init(pm types.ProcessManager, cn types.Provider) error {
 			}
 			return txmps, nil
 		})
+		as.Set("createMultiSigTransfer", func(ID interface{}, arg *apiserver.Argument) (interface{}, error) {
+			if arg.Len() != 3 {
+				return nil, apiserver.ErrInvalidArgument
+			}
+			fromStr, err := arg.String(0)
+			if err != nil {
+				return nil, err
+			}
+			from, err := common.ParseAddress(fromStr)
+			if err != nil {
+				return nil, err
+			}
+			toStr, err := arg.String(1)
+			if err != nil {
+				return nil, err
+			}
+			to, err := common.ParseAddress(toStr)
+			if err != nil {
+				return nil, err
+			}
+			amStr, err := arg.String(2)
+			if err != nil {
+				return nil, err
+			}
+			am, err := amount.ParseAmount(amStr)
+			if err != nil {
+				return nil, err
+			}
+
+			s.Lock()
+			Seq, has := s.seqMap[from]
+			ChainSeq := s.cn.Seq(from)
+			if !has || Seq < ChainSeq {
+				Seq = ChainSeq
+			}
+			Seq++
+			s.seqMap[from] = Seq
+			s.Unlock()
+
+			tx := &vault.Transfer{
+				Timestamp_: uint64(time.Now().UnixNano()),
+				Seq_:       Seq,
+				From_:      from,
+				To:         to,
+				Amount:     am,
+			}
+			TxHash, err := s.CreateMultiSigTx(tx)
+			if err != nil {
+				s.Lock()
+				s.seqMap[from] = Seq - 1
+				s.Unlock()
+				return nil, err
+			}
+			return TxHash, nil
+		})
+		as.Set("multiSigTx", func(ID interface{}, arg *apiserver.Argument) (interface{}, error) {
+			if arg.Len() != 1 {
+				return nil, apiserver.ErrInvalidArgument
+			}
+			TxHash, err := parseTxHash(arg, 0)
+			if err != nil {
+				return nil, err
+			}
+			tx, signers, Required, err := s.MultiSigTx(TxHash)
+			if err != nil {
+				return nil, err
+			}
+			fc := encoding.Factory("transaction")
+			bs, err := tx.MarshalJSON()
+			if err != nil {
+				return nil, err
+			}
+			mp := map[string]interface{}{}
+			if err := json.Unmarshal(bs, &mp); err != nil {
+				return nil, err
+			}
+			t, err := fc.TypeOf(tx)
+			if err != nil {
+				return nil, err
+			}
+			name, err := fc.TypeName(t)
+			if err != nil {
+				return nil, err
+			}
+			mp["tx_hash"] = TxHash.String()
+			mp["type"] = name
+			mp["signers"] = signers
+			mp["required"] = Required
+			mp["ready"] = len(signers) >= Required
+			return mp, nil
+		})
+		as.Set("signMultiSigTx", func(ID interface{}, arg *apiserver.Argument) (interface{}, error) {
+			if arg.Len() != 3 {
+				return nil, apiserver.ErrInvalidArgument
+			}
+			TxHash, err := parseTxHash(arg, 0)
+			if err != nil {
+				return nil, err
+			}
+			name, err := arg.String(1)
+			if err != nil {
+				return nil, err
+			}
+			Password, err := arg.String(2)
+			if err != nil {
+				return nil, err
+			}
+			if err := s.SignMultiSigTx(TxHash, name, Password); err != nil {
+				return nil, err
+			}
+			return TxHash, nil
+		})
+		as.Set("addMultiSigSignature", func(ID interface{}, arg *apiserver.Argument) (interface{}, error) {
+			if arg.Len() != 2 {
+				return nil, apiserver.ErrInvalidArgument
+			}
+			TxHash, err := parseTxHash(arg, 0)
+			if err != nil {
+				return nil, err
+			}
+			sigStr, err := arg.String(1)
+			if err != nil {
+				return nil, err
+			}
+			sig, err := common.ParseSignature(sigStr)
+			if err != nil {
+				return nil, err
+			}
+			if err := s.AddMultiSigSignature(TxHash, sig); err != nil {
+				return nil, err
+			}
+			return TxHash, nil
+		})
+		as.Set("exportMultiSigTx", func(ID interface{}, arg *apiserver.Argument) (interface{}, error) {
+			if arg.Len() != 1 {
+				return nil, apiserver.ErrInvalidArgument
+			}
+			TxHash, err := parseTxHash(arg, 0)
+			if err != nil {
+				return nil, err
+			}
+			bs, err := s.ExportMultiSigTx(TxHash)
+			if err != nil {
+				return nil, err
+			}
+			return hex.EncodeToString(bs), nil
+		})
+		as.Set("importMultiSigTx", func(ID interface{}, arg *apiserver.Argument) (interface{}, error) {
+			if arg.Len() != 1 {
+				return nil, apiserver.ErrInvalidArgument
+			}
+			hexStr, err := arg.String(0)
+			if err != nil {
+				return nil, err
+			}
+			bs, err := hex.DecodeString(hexStr)
+			if err != nil {
+				return nil, err
+			}
+			TxHash, err := s.ImportMultiSigTx(bs)
+			if err != nil {
+				return nil, err
+			}
+			return TxHash, nil
+		})
+		as.Set("broadcastMultiSigTx", func(ID interface{}, arg *apiserver.Argument) (interface{}, error) {
+			if arg.Len() != 1 {
+				return nil, apiserver.ErrInvalidArgument
+			}
+			TxHash, err := parseTxHash(arg, 0)
+			if err != nil {
+				return nil, err
+			}
+			if err := s.BroadcastMultiSigTx(TxHash); err != nil {
+				return nil, err
+			}
+			return TxHash, nil
+		})
 	}
 	return nil
 }
 
+func parseTxHash(arg *apiserver.Argument, i int) (hash.Hash256, error) {
+	str, err := arg.String(i)
+	if err != nil {
+		return hash.Hash256{}, err
+	}
+	TxHash, err := hash.ParseHash(str)
+	if err != nil {
+		return hash.Hash256{}, err
+	}
+	return TxHash, nil
+}
+
 // OnLoadChain called when the chain loaded
 func (s *Bank) OnLoadChain(loader types.Loader) error {
 	return nil
@@ -562,6 +832,39 @@ func (s *Bank) OnBlockConnected(b *types.Block, events []types.Event, loader typ
 							continue
 						}
 					}
+				} else if tx, is := t.(*vault.MultiTransfer); is {
+					_, err := txn.Get(toAddressNameKey(tx.From()))
+					if err != nil {
+						isRelated := false
+						for _, To := range tx.ToAddresses {
+							if _, err := txn.Get(toAddressNameKey(To)); err == nil {
+								isRelated = true
+								break
+							}
+						}
+						if !isRelated {
+							continue
+						}
+					}
+				} else if tx, is := t.(*token.Transfer); is {
+					_, err := txn.Get(toAddressNameKey(tx.From()))
+					if err != nil {
+						_, err := txn.Get(toAddressNameKey(tx.To))
+						if err != nil {
+							continue
+						}
+					}
+				} else if tx, is := t.(*token.TransferFrom); is {
+					_, err := txn.Get(toAddressNameKey(tx.From()))
+					if err != nil {
+						_, err := txn.Get(toAddressNameKey(tx.Owner))
+						if err != nil {
+							_, err := txn.Get(toAddressNameKey(tx.To))
+							if err != nil {
+								continue
+							}
+						}
+					}
 				} else {
 					_, err := txn.Get(toAddressNameKey(at.From()))
 					if err != nil {
@@ -604,6 +907,8 @@ func (s *Bank) OnBlockConnected(b *types.Block, events []types.Event, loader typ
 						s.removeUnstaking(tx.HyperFormulator, tx.From(), tx.UnstakedHeight, tx.Amount)
 					case *vault.Transfer:
 						s.addTransfer(TXID, tx)
+					case *vault.MultiTransfer:
+						s.addMultiTransfer(TXID, tx)
 					}
 				}
 			}
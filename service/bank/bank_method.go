@@ -10,6 +10,7 @@ import (
 	"github.com/fletaio/fleta/core/types"
 	"github.com/fletaio/fleta/encoding"
 	"github.com/fletaio/fleta/process/formulator"
+	"github.com/fletaio/fleta/process/token"
 	"github.com/fletaio/fleta/process/vault"
 )
 
@@ -88,6 +89,31 @@ func (s *Bank) addTransfer(txid string, tx *vault.Transfer) error {
 	return nil
 }
 
+func (s *Bank) addMultiTransfer(txid string, tx *vault.MultiTransfer) error {
+	if _, err := s.db.RPush(toTransferSendListKey(tx.From()), []byte(txid)); err != nil {
+		return err
+	}
+	for _, To := range multiTransferRecipients(tx) {
+		if _, err := s.db.RPush(toTransferRecvListKey(To), []byte(txid)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func multiTransferRecipients(tx *vault.MultiTransfer) []common.Address {
+	addrMap := map[common.Address]bool{}
+	list := []common.Address{}
+	for _, To := range tx.ToAddresses {
+		if To == tx.From() || addrMap[To] {
+			continue
+		}
+		addrMap[To] = true
+		list = append(list, To)
+	}
+	return list
+}
+
 func (s *Bank) addTransaction(txid string, t uint16, at chain.AccountTransaction, res uint8) error {
 	if res == 1 {
 		switch tx := at.(type) {
@@ -97,6 +123,29 @@ func (s *Bank) addTransaction(txid string, t uint16, at chain.AccountTransaction
 					return err
 				}
 			}
+		case *vault.MultiTransfer:
+			for _, To := range multiTransferRecipients(tx) {
+				if _, err := s.db.RPush(toTransactionListKey(To), []byte(txid)); err != nil {
+					return err
+				}
+			}
+		case *token.Transfer:
+			if tx.From() != tx.To {
+				if _, err := s.db.RPush(toTransactionListKey(tx.To), []byte(txid)); err != nil {
+					return err
+				}
+			}
+		case *token.TransferFrom:
+			if tx.Owner != tx.From() {
+				if _, err := s.db.RPush(toTransactionListKey(tx.Owner), []byte(txid)); err != nil {
+					return err
+				}
+			}
+			if tx.To != tx.From() && tx.To != tx.Owner {
+				if _, err := s.db.RPush(toTransactionListKey(tx.To), []byte(txid)); err != nil {
+					return err
+				}
+			}
 		case *formulator.Unstaking:
 			if _, err := s.db.RPush(toTransactionListKey(tx.HyperFormulator), []byte(txid)); err != nil {
 				return err
@@ -11,4 +11,9 @@ var (
 	ErrInvalidTXID            = errors.New("invalid txid")
 	ErrTransactionTimeout     = errors.New("transaction timeout")
 	ErrTransactionFailed      = errors.New("transaction failed")
+	ErrNotMultiAccount        = errors.New("not multi account")
+	ErrNotExistMultiSigTx     = errors.New("not exist multisig transaction")
+	ErrNotAccountTransaction  = errors.New("not account transaction")
+	ErrInvalidMultiSigner     = errors.New("invalid multisig signer")
+	ErrInsufficientSignatures = errors.New("insufficient signatures")
 )
@@ -0,0 +1,253 @@
+package bank
+
+import (
+	"github.com/fletaio/fleta/common"
+	"github.com/fletaio/fleta/common/hash"
+	"github.com/fletaio/fleta/core/chain"
+	"github.com/fletaio/fleta/core/types"
+	"github.com/fletaio/fleta/encoding"
+	"github.com/fletaio/fleta/process/vault"
+)
+
+// CreateMultiSigTx stores the transaction of the multi account to collect signatures
+func (s *Bank) CreateMultiSigTx(tx types.Transaction) (hash.Hash256, error) {
+	at, is := tx.(chain.AccountTransaction)
+	if !is {
+		return hash.Hash256{}, ErrNotAccountTransaction
+	}
+	if _, err := s.multiAccount(at.From()); err != nil {
+		return hash.Hash256{}, err
+	}
+	fc := encoding.Factory("transaction")
+	t, err := fc.TypeOf(tx)
+	if err != nil {
+		return hash.Hash256{}, err
+	}
+	data, err := encoding.Marshal(tx)
+	if err != nil {
+		return hash.Hash256{}, err
+	}
+	mtx := &MultiSigTransaction{
+		Type:       t,
+		Data:       data,
+		Signatures: []common.Signature{},
+	}
+	TxHash := chain.HashTransactionByType(s.cn.ChainID(), t, tx)
+	if _, err := s.getMultiSigTx(TxHash); err == nil {
+		return TxHash, nil
+	}
+	if err := s.setMultiSigTx(TxHash, mtx); err != nil {
+		return hash.Hash256{}, err
+	}
+	return TxHash, nil
+}
+
+// ImportMultiSigTx stores the partially signed transaction exported from other party
+func (s *Bank) ImportMultiSigTx(bs []byte) (hash.Hash256, error) {
+	mtx := &MultiSigTransaction{}
+	if err := encoding.Unmarshal(bs, &mtx); err != nil {
+		return hash.Hash256{}, err
+	}
+	tx, err := mtx.Transaction()
+	if err != nil {
+		return hash.Hash256{}, err
+	}
+	TxHash, err := s.CreateMultiSigTx(tx)
+	if err != nil {
+		return hash.Hash256{}, err
+	}
+	for _, sig := range mtx.Signatures {
+		if err := s.AddMultiSigSignature(TxHash, sig); err != nil {
+			return hash.Hash256{}, err
+		}
+	}
+	return TxHash, nil
+}
+
+// ExportMultiSigTx returns the encoded partially signed transaction
+func (s *Bank) ExportMultiSigTx(TxHash hash.Hash256) ([]byte, error) {
+	mtx, err := s.getMultiSigTx(TxHash)
+	if err != nil {
+		return nil, err
+	}
+	bs, err := encoding.Marshal(mtx)
+	if err != nil {
+		return nil, err
+	}
+	return bs, nil
+}
+
+// SignMultiSigTx adds the signature of the key to the partially signed transaction
+func (s *Bank) SignMultiSigTx(TxHash hash.Hash256, name string, Password string) error {
+	sig, err := s.Sign(name, Password, TxHash)
+	if err != nil {
+		return err
+	}
+	if err := s.AddMultiSigSignature(TxHash, sig); err != nil {
+		return err
+	}
+	return nil
+}
+
+// AddMultiSigSignature adds the signature to the partially signed transaction
+func (s *Bank) AddMultiSigSignature(TxHash hash.Hash256, sig common.Signature) error {
+	s.Lock()
+	defer s.Unlock()
+
+	mtx, err := s.getMultiSigTx(TxHash)
+	if err != nil {
+		return err
+	}
+	tx, err := mtx.Transaction()
+	if err != nil {
+		return err
+	}
+	acc, err := s.multiAccount(tx.(chain.AccountTransaction).From())
+	if err != nil {
+		return err
+	}
+	pubkey, err := common.RecoverPubkey(TxHash, sig)
+	if err != nil {
+		return err
+	}
+	signer := common.NewPublicHash(pubkey)
+	if !acc.IsKeyHash(signer) {
+		return ErrInvalidMultiSigner
+	}
+	signers, err := mtx.Signers(TxHash)
+	if err != nil {
+		return err
+	}
+	for _, v := range signers {
+		if v == signer {
+			return nil
+		}
+	}
+	mtx.Signatures = append(mtx.Signatures, sig)
+	if err := s.setMultiSigTx(TxHash, mtx); err != nil {
+		return err
+	}
+	return nil
+}
+
+// MultiSigTx returns the partially signed transaction, its signers and the required signer count
+func (s *Bank) MultiSigTx(TxHash hash.Hash256) (types.Transaction, []common.PublicHash, int, error) {
+	mtx, err := s.getMultiSigTx(TxHash)
+	if err != nil {
+		return nil, nil, 0, err
+	}
+	tx, err := mtx.Transaction()
+	if err != nil {
+		return nil, nil, 0, err
+	}
+	signers, err := mtx.Signers(TxHash)
+	if err != nil {
+		return nil, nil, 0, err
+	}
+	acc, err := s.multiAccount(tx.(chain.AccountTransaction).From())
+	if err != nil {
+		return nil, nil, 0, err
+	}
+	return tx, signers, int(acc.Required), nil
+}
+
+// BroadcastMultiSigTx sends the transaction when its signatures meet the threshold of the multi account
+func (s *Bank) BroadcastMultiSigTx(TxHash hash.Hash256) error {
+	mtx, err := s.getMultiSigTx(TxHash)
+	if err != nil {
+		return err
+	}
+	tx, err := mtx.Transaction()
+	if err != nil {
+		return err
+	}
+	at := tx.(chain.AccountTransaction)
+	acc, err := s.multiAccount(at.From())
+	if err != nil {
+		return err
+	}
+	if len(mtx.Signatures) < int(acc.Required) {
+		return ErrInsufficientSignatures
+	}
+	if err := s.nd.AddTx(tx, mtx.Signatures); err != nil {
+		return err
+	}
+	if err := s.addPending(at); err != nil {
+		return err
+	}
+	if _, err := s.db.HDel(tagMultiSig, TxHash[:]); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (s *Bank) multiAccount(addr common.Address) (*vault.MultiAccount, error) {
+	acc, err := s.cn.NewLoaderWrapper(1).Account(addr)
+	if err != nil {
+		return nil, err
+	}
+	macc, is := acc.(*vault.MultiAccount)
+	if !is {
+		return nil, ErrNotMultiAccount
+	}
+	return macc, nil
+}
+
+func (s *Bank) getMultiSigTx(TxHash hash.Hash256) (*MultiSigTransaction, error) {
+	bs, err := s.db.HGet(tagMultiSig, TxHash[:])
+	if err != nil {
+		return nil, err
+	}
+	if len(bs) == 0 {
+		return nil, ErrNotExistMultiSigTx
+	}
+	mtx := &MultiSigTransaction{}
+	if err := encoding.Unmarshal(bs, &mtx); err != nil {
+		return nil, err
+	}
+	return mtx, nil
+}
+
+func (s *Bank) setMultiSigTx(TxHash hash.Hash256, mtx *MultiSigTransaction) error {
+	bs, err := encoding.Marshal(mtx)
+	if err != nil {
+		return err
+	}
+	if _, err := s.db.HSet(tagMultiSig, TxHash[:], bs); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Transaction returns the decoded transaction
+func (mtx *MultiSigTransaction) Transaction() (types.Transaction, error) {
+	fc := encoding.Factory("transaction")
+	t, err := fc.Create(mtx.Type)
+	if err != nil {
+		return nil, err
+	}
+	if err := encoding.Unmarshal(mtx.Data, &t); err != nil {
+		return nil, err
+	}
+	tx, is := t.(types.Transaction)
+	if !is {
+		return nil, ErrNotAccountTransaction
+	}
+	if _, is := tx.(chain.AccountTransaction); !is {
+		return nil, ErrNotAccountTransaction
+	}
+	return tx, nil
+}
+
+// Signers returns public hashes of collected signatures
+func (mtx *MultiSigTransaction) Signers(TxHash hash.Hash256) ([]common.PublicHash, error) {
+	signers := make([]common.PublicHash, 0, len(mtx.Signatures))
+	for _, sig := range mtx.Signatures {
+		pubkey, err := common.RecoverPubkey(TxHash, sig)
+		if err != nil {
+			return nil, err
+		}
+		signers = append(signers, common.NewPublicHash(pubkey))
+	}
+	return signers, nil
+}
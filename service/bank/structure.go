@@ -1,7 +1,16 @@
 package bank
 
+import "github.com/fletaio/fleta/common"
+
 type Transaction struct {
 	Type   uint16
 	Data   []byte
 	Result uint8
 }
+
+// MultiSigTransaction is a partially signed transaction of the multi account
+type MultiSigTransaction struct {
+	Type       uint16
+	Data       []byte
+	Signatures []common.Signature
+}
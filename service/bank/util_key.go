@@ -21,6 +21,7 @@ var (
 	tagAccountAddress   = []byte{4, 1}
 	tagAddressKeyHash   = []byte{4, 2}
 	tagUnstaking        = []byte{5, 1}
+	tagMultiSig         = []byte{6, 1}
 )
 
 func toSecretKey(name string) []byte {
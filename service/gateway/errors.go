@@ -0,0 +1,25 @@
+package gateway
+
+import "errors"
+
+// errors
+var (
+	ErrNotExistWithdrawalJob        = errors.New("not exist withdrawal job")
+	ErrCompletedWithdrawalJob       = errors.New("completed withdrawal job")
+	ErrNoRelayerKey                 = errors.New("no relayer key")
+	ErrInvalidDepositFormat         = errors.New("invalid deposit format")
+	ErrRelayerNodeNotInitialized    = errors.New("relayer node not initialized")
+	ErrNotSignableTransaction       = errors.New("not signable transaction")
+	ErrNotConfirmedDeposit          = errors.New("not confirmed deposit")
+	ErrMismatchedDeposit            = errors.New("mismatched deposit")
+	ErrMismatchedWithdrawalJob      = errors.New("mismatched withdrawal job")
+	ErrNotCommitteeMember           = errors.New("not committee member")
+	ErrInsufficientRelayerSignature = errors.New("insufficient relayer signature")
+	ErrInvalidPeerResponse          = errors.New("invalid peer response")
+	ErrInvalidPayoutFormat          = errors.New("invalid payout format")
+	ErrNotConfirmedPayout           = errors.New("not confirmed payout")
+	ErrMismatchedPayout             = errors.New("mismatched payout")
+)
+
+// errDepositNotSubmitted is returned when the submission of the deposit failed and is recorded to be retried
+var errDepositNotSubmitted = errors.New("deposit not submitted")
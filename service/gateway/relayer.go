@@ -0,0 +1,550 @@
+package gateway
+
+import (
+	"encoding/hex"
+	"sync"
+	"time"
+
+	"github.com/fletaio/fleta/common"
+	"github.com/fletaio/fleta/common/amount"
+	"github.com/fletaio/fleta/common/hash"
+	"github.com/fletaio/fleta/common/key"
+	"github.com/fletaio/fleta/common/rlog"
+	"github.com/fletaio/fleta/core/backend"
+	"github.com/fletaio/fleta/core/chain"
+	"github.com/fletaio/fleta/core/types"
+	"github.com/fletaio/fleta/encoding"
+	"github.com/fletaio/fleta/process/gateway"
+	"github.com/fletaio/fleta/service/apiserver"
+)
+
+// RelayerConfig is the configuration of the relayer of the platform
+// Key is the committee member key of this relayer and Peers are the other relayers of the committee
+// only the coordinator submits transactions and the others sign them after matching with their own watchers
+// a deposit failed MaxSubmitAttempts times is parked and is not submitted again
+type RelayerConfig struct {
+	Platform          string
+	GatewayAddress    common.Address
+	Key               key.Key
+	Peers             []RelayerPeer
+	IsCoordinator     bool
+	PollInterval      time.Duration
+	ResubmitBlocks    uint32
+	MaxSubmitAttempts uint32
+}
+
+// TxSubmitter adds the signed transaction to the network
+type TxSubmitter interface {
+	AddTx(tx types.Transaction, sigs []common.Signature) error
+}
+
+// Relayer submits TokenIn transactions for deposits of the foreign chain and tracks withdrawal jobs from TokenOut transactions
+// the mutex guards records of the store and submitLock orders submissions of the coordinator
+// signatures of peers are collected only under submitLock so block connection is not blocked by peers
+type Relayer struct {
+	sync.Mutex
+	*types.ServiceBase
+	Config     *RelayerConfig
+	st         backend.StoreBackend
+	watcher    ForeignChainWatcher
+	cn         types.Provider
+	nd         TxSubmitter
+	gateway    *gateway.Gateway
+	submitLock sync.Mutex
+	lastSeq    uint64
+	isClose    bool
+}
+
+// NewRelayer returns a Relayer
+func NewRelayer(Config *RelayerConfig, st backend.StoreBackend, watcher ForeignChainWatcher) *Relayer {
+	if Config.PollInterval == 0 {
+		Config.PollInterval = 10 * time.Second
+	}
+	if Config.ResubmitBlocks == 0 {
+		Config.ResubmitBlocks = 30
+	}
+	if Config.MaxSubmitAttempts == 0 {
+		Config.MaxSubmitAttempts = 10
+	}
+	s := &Relayer{
+		Config:  Config,
+		st:      st,
+		watcher: watcher,
+	}
+	return s
+}
+
+// Name returns the name of the service
+func (s *Relayer) Name() string {
+	return "fleta.gateway.relayer"
+}
+
+// SetNode sets the node used to submit transactions
+func (s *Relayer) SetNode(nd TxSubmitter) {
+	s.nd = nd
+}
+
+// Init called when initialize service
+func (s *Relayer) Init(pm types.ProcessManager, cn types.Provider) error {
+	s.cn = cn
+
+	if s.Config.Key == nil {
+		return ErrNoRelayerKey
+	}
+	if vp, err := pm.ProcessByName("fleta.gateway"); err != nil {
+		return err
+	} else if v, is := vp.(*gateway.Gateway); !is {
+		return types.ErrInvalidProcess
+	} else {
+		s.gateway = v
+	}
+
+	if vs, err := pm.ServiceByName("fleta.apiserver"); err != nil {
+		//ignore when not loaded
+	} else if v, is := vs.(*apiserver.APIServer); !is {
+		//ignore when not loaded
+	} else {
+		as, err := v.JRPC("relayer")
+		if err != nil {
+			return err
+		}
+		as.Set("withdrawalJobs", func(ID interface{}, arg *apiserver.Argument) (interface{}, error) {
+			return s.WithdrawalJobs()
+		})
+		as.Set("completeWithdrawal", func(ID interface{}, arg *apiserver.Argument) (interface{}, error) {
+			if arg.Len() != 2 {
+				return nil, apiserver.ErrInvalidArgument
+			}
+			CoinTXID, err := arg.String(0)
+			if err != nil {
+				return nil, err
+			}
+			arg1, err := arg.String(1)
+			if err != nil {
+				return nil, err
+			}
+			ERC20TXID, err := hash.ParseHash(arg1)
+			if err != nil {
+				return nil, err
+			}
+			return s.CompleteWithdrawal(CoinTXID, ERC20TXID)
+		})
+		as.Set("signTransaction", func(ID interface{}, arg *apiserver.Argument) (interface{}, error) {
+			if arg.Len() != 2 {
+				return nil, apiserver.ErrInvalidArgument
+			}
+			t, err := arg.Uint16(0)
+			if err != nil {
+				return nil, err
+			}
+			arg1, err := arg.String(1)
+			if err != nil {
+				return nil, err
+			}
+			bs, err := hex.DecodeString(arg1)
+			if err != nil {
+				return nil, err
+			}
+			fc := encoding.Factory("transaction")
+			v, err := fc.Create(t)
+			if err != nil {
+				return nil, err
+			}
+			if err := encoding.Unmarshal(bs, &v); err != nil {
+				return nil, err
+			}
+			tx, is := v.(types.Transaction)
+			if !is {
+				return nil, ErrNotSignableTransaction
+			}
+			sig, err := s.SignTransaction(tx)
+			if err != nil {
+				return nil, err
+			}
+			return sig.String(), nil
+		})
+	}
+	return nil
+}
+
+// OnBlockConnected called when a block is connected to the chain
+func (s *Relayer) OnBlockConnected(b *types.Block, events []types.Event, loader types.Loader) {
+	s.Lock()
+	defer s.Unlock()
+
+	for i, t := range b.Transactions {
+		if b.TransactionResults[i] != 1 {
+			continue
+		}
+		switch tx := t.(type) {
+		case *gateway.TokenIn:
+			if tx.Platform == s.Config.Platform {
+				s.markDepositProcessed(tx)
+			}
+		case *gateway.TokenOut:
+			if tx.Platform == s.Config.Platform {
+				s.addWithdrawalJob(&WithdrawalJob{
+					CoinTXID: types.TransactionID(b.Header.Height, uint16(i)),
+					CoinFrom: tx.From(),
+					ERC20To:  tx.ERC20To,
+					Amount:   tx.Amount,
+				})
+			}
+		case *gateway.TokenLeave:
+			if tx.Platform == s.Config.Platform {
+				s.markWithdrawalCompleted(tx.CoinTXID, tx.ERC20TXID)
+			}
+		case *gateway.RefundWithdrawal:
+			if tx.Platform == s.Config.Platform {
+				s.markWithdrawalCompleted(tx.CoinTXID, hash.Hash256{})
+			}
+		}
+	}
+}
+
+// Close stops the relayer
+func (s *Relayer) Close() {
+	s.Lock()
+	defer s.Unlock()
+
+	s.isClose = true
+}
+
+// Run polls the watcher and submits deposits until closed
+func (s *Relayer) Run() {
+	for {
+		s.Lock()
+		isClose := s.isClose
+		s.Unlock()
+		if isClose {
+			return
+		}
+		if !s.Config.IsCoordinator || s.nd != nil {
+			if err := s.relayDeposits(); err != nil {
+				rlog.Println("relayDeposits", err)
+			}
+		}
+		if s.Config.IsCoordinator && s.nd != nil {
+			if err := s.resubmitDeposits(); err != nil {
+				rlog.Println("resubmitDeposits", err)
+			}
+		}
+		time.Sleep(s.Config.PollInterval)
+	}
+}
+
+func (s *Relayer) relayDeposits() error {
+	Cursor, err := s.cursor()
+	if err != nil {
+		return err
+	}
+	deposits, NextCursor, err := s.watcher.ConfirmedDeposits(Cursor)
+	if err != nil {
+		return err
+	}
+	for _, dp := range deposits {
+		if s.Config.IsCoordinator {
+			if err := s.submitDeposit(dp); err != nil {
+				if err != errDepositNotSubmitted {
+					return err
+				}
+			}
+		} else {
+			if err := s.recordDeposit(dp); err != nil {
+				return err
+			}
+		}
+	}
+	if NextCursor != Cursor {
+		if err := s.setCursor(NextCursor); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *Relayer) resubmitDeposits() error {
+	records, err := s.pendingDeposits()
+	if err != nil {
+		return err
+	}
+	Height := s.cn.Height()
+	for _, rec := range records {
+		if rec.SubmitHeight+s.Config.ResubmitBlocks > Height {
+			continue
+		}
+		if err := s.submitDeposit(rec.Deposit); err != nil {
+			if err != errDepositNotSubmitted {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// recordDeposit stores the deposit confirmed by the watcher of this relayer to sign TokenIn of the coordinator
+func (s *Relayer) recordDeposit(dp *Deposit) error {
+	s.Lock()
+	defer s.Unlock()
+
+	if _, err := s.depositRecord(dp.ERC20TXID); err == nil {
+		return nil
+	} else if err != backend.ErrNotExistKey {
+		return err
+	}
+	return s.setDepositRecord(&depositRecord{
+		Deposit: dp,
+	})
+}
+
+// submitDeposit submits TokenIn of the deposit with signatures of the committee
+// a failed submission is recorded to be retried by resubmitDeposits and it returns errDepositNotSubmitted
+func (s *Relayer) submitDeposit(dp *Deposit) error {
+	s.submitLock.Lock()
+	defer s.submitLock.Unlock()
+
+	s.Lock()
+	rec, err := s.depositRecord(dp.ERC20TXID)
+	s.Unlock()
+	if err != nil {
+		if err != backend.ErrNotExistKey {
+			return err
+		}
+		rec = &depositRecord{
+			Deposit: dp,
+		}
+	} else if rec.IsProcessed || rec.IsParked || rec.SubmitHeight+s.Config.ResubmitBlocks > s.cn.Height() {
+		return nil
+	}
+	loader := s.cn.NewLoaderWrapper(s.gateway.ID())
+	if is, err := s.gateway.IsProcessedERC20TXID(loader, s.Config.Platform, dp.ERC20TXID); err != nil {
+		return err
+	} else if is {
+		rec.IsProcessed = true
+		return s.updateDepositRecord(rec)
+	}
+
+	Seq := s.nextSeq()
+	tx := &gateway.TokenIn{
+		Timestamp_:  uint64(time.Now().UnixNano()),
+		Seq_:        Seq,
+		From_:       s.Config.GatewayAddress,
+		Platform:    s.Config.Platform,
+		ERC20TXID:   dp.ERC20TXID,
+		ERC20From:   dp.ERC20From,
+		ToAddresses: []common.Address{dp.To},
+		Amounts:     []*amount.Amount{dp.Amount},
+	}
+	rec.SubmitHeight = s.cn.Height()
+	TxHash, err := s.signAndAdd(tx)
+	if err != nil {
+		s.lastSeq = Seq - 1
+		rlog.Println("submitDeposit", dp.ERC20TXID.String(), err)
+
+		rec.Attempts++
+		if rec.Attempts >= s.Config.MaxSubmitAttempts {
+			rec.IsParked = true
+			rlog.Println("submitDeposit", dp.ERC20TXID.String(), "parked")
+		}
+		if err := s.updateDepositRecord(rec); err != nil {
+			return err
+		}
+		return errDepositNotSubmitted
+	}
+	rec.TxHash = TxHash
+	return s.updateDepositRecord(rec)
+}
+
+func (s *Relayer) updateDepositRecord(rec *depositRecord) error {
+	s.Lock()
+	defer s.Unlock()
+
+	return s.setDepositRecord(rec)
+}
+
+// CompleteWithdrawal submits the TokenLeave transaction of the withdrawal job paid by the foreign transaction
+func (s *Relayer) CompleteWithdrawal(CoinTXID string, ERC20TXID hash.Hash256) (hash.Hash256, error) {
+	s.submitLock.Lock()
+	defer s.submitLock.Unlock()
+
+	if s.nd == nil {
+		return hash.Hash256{}, ErrRelayerNodeNotInitialized
+	}
+	s.Lock()
+	job, err := s.withdrawalJob(CoinTXID)
+	s.Unlock()
+	if err != nil {
+		return hash.Hash256{}, err
+	}
+	if job.IsCompleted {
+		return hash.Hash256{}, ErrCompletedWithdrawalJob
+	}
+	if is, err := s.isPaused(); err != nil {
+		return hash.Hash256{}, err
+	} else if is {
+		return hash.Hash256{}, gateway.ErrPlatformPaused
+	}
+	if err := s.checkPayout(job, ERC20TXID); err != nil {
+		return hash.Hash256{}, err
+	}
+	Seq := s.nextSeq()
+	tx := &gateway.TokenLeave{
+		Timestamp_: uint64(time.Now().UnixNano()),
+		Seq_:       Seq,
+		From_:      s.Config.GatewayAddress,
+		Platform:   s.Config.Platform,
+		CoinTXID:   job.CoinTXID,
+		CoinFrom:   job.CoinFrom,
+		ERC20TXID:  ERC20TXID,
+		ERC20To:    job.ERC20To,
+		Amount:     job.Amount,
+	}
+	TxHash, err := s.signAndAdd(tx)
+	if err != nil {
+		s.lastSeq = Seq - 1
+		return hash.Hash256{}, err
+	}
+	return TxHash, nil
+}
+
+// checkPayout checks that the foreign transaction is the confirmed payout of the withdrawal job by the watcher of this relayer
+func (s *Relayer) checkPayout(job *WithdrawalJob, ERC20TXID hash.Hash256) error {
+	po, err := s.watcher.ConfirmedPayout(ERC20TXID)
+	if err != nil {
+		return err
+	}
+	if po.ERC20To != job.ERC20To || !po.Amount.Equal(job.Amount) {
+		return ErrMismatchedPayout
+	}
+	return nil
+}
+
+func (s *Relayer) nextSeq() uint64 {
+	ChainSeq := s.cn.Seq(s.Config.GatewayAddress)
+	if s.lastSeq < ChainSeq {
+		s.lastSeq = ChainSeq
+	}
+	s.lastSeq++
+	return s.lastSeq
+}
+
+// SignTransaction signs the transaction of the coordinator when it matches the deposit or the withdrawal job known to this relayer
+// TokenLeave is signed only when the watcher of this relayer confirms the foreign payout of the job
+func (s *Relayer) SignTransaction(tx types.Transaction) (common.Signature, error) {
+	switch tx := tx.(type) {
+	case *gateway.TokenIn:
+		if tx.Platform != s.Config.Platform || tx.From() != s.Config.GatewayAddress {
+			return common.Signature{}, ErrNotSignableTransaction
+		}
+		if len(tx.ToAddresses) != 1 || len(tx.Amounts) != 1 {
+			return common.Signature{}, ErrNotSignableTransaction
+		}
+		s.Lock()
+		rec, err := s.depositRecord(tx.ERC20TXID)
+		s.Unlock()
+		if err != nil {
+			if err == backend.ErrNotExistKey {
+				return common.Signature{}, ErrNotConfirmedDeposit
+			}
+			return common.Signature{}, err
+		}
+		dp := rec.Deposit
+		if dp.ERC20From != tx.ERC20From || dp.To != tx.ToAddresses[0] || !dp.Amount.Equal(tx.Amounts[0]) {
+			return common.Signature{}, ErrMismatchedDeposit
+		}
+	case *gateway.TokenLeave:
+		if tx.Platform != s.Config.Platform || tx.From() != s.Config.GatewayAddress {
+			return common.Signature{}, ErrNotSignableTransaction
+		}
+		s.Lock()
+		job, err := s.withdrawalJob(tx.CoinTXID)
+		s.Unlock()
+		if err != nil {
+			return common.Signature{}, err
+		}
+		if job.IsCompleted {
+			return common.Signature{}, ErrCompletedWithdrawalJob
+		}
+		if job.CoinFrom != tx.CoinFrom || job.ERC20To != tx.ERC20To || !job.Amount.Equal(tx.Amount) {
+			return common.Signature{}, ErrMismatchedWithdrawalJob
+		}
+		if is, err := s.isPaused(); err != nil {
+			return common.Signature{}, err
+		} else if is {
+			return common.Signature{}, gateway.ErrPlatformPaused
+		}
+		if err := s.checkPayout(job, tx.ERC20TXID); err != nil {
+			return common.Signature{}, err
+		}
+	default:
+		return common.Signature{}, ErrNotSignableTransaction
+	}
+	return s.Config.Key.Sign(chain.HashTransaction(s.cn.ChainID(), tx))
+}
+
+// collectSignatures signs the transaction by the key of this relayer and asks peers until the threshold of the committee is met
+func (s *Relayer) collectSignatures(tx types.Transaction) (hash.Hash256, []common.Signature, error) {
+	loader := s.cn.NewLoaderWrapper(s.gateway.ID())
+	cm, err := s.gateway.Committee(loader, s.Config.Platform)
+	if err != nil {
+		return hash.Hash256{}, nil, err
+	}
+	memberMap := map[common.PublicHash]bool{}
+	for _, pubhash := range cm.KeyHashes {
+		memberMap[pubhash] = true
+	}
+
+	TxHash := chain.HashTransaction(s.cn.ChainID(), tx)
+	sigs := []common.Signature{}
+	signedMap := map[common.PublicHash]bool{}
+	addSignature := func(sig common.Signature) error {
+		pubkey, err := common.RecoverPubkey(TxHash, sig)
+		if err != nil {
+			return err
+		}
+		pubhash := common.NewPublicHash(pubkey)
+		if !memberMap[pubhash] {
+			return ErrNotCommitteeMember
+		}
+		if !signedMap[pubhash] {
+			signedMap[pubhash] = true
+			sigs = append(sigs, sig)
+		}
+		return nil
+	}
+
+	if sig, err := s.Config.Key.Sign(TxHash); err != nil {
+		return hash.Hash256{}, nil, err
+	} else if err := addSignature(sig); err != nil {
+		return hash.Hash256{}, nil, err
+	}
+	for _, peer := range s.Config.Peers {
+		if len(sigs) >= int(cm.Required) {
+			break
+		}
+		sig, err := peer.Sign(tx)
+		if err != nil {
+			rlog.Println("RelayerPeer.Sign", err)
+			continue
+		}
+		if err := addSignature(sig); err != nil {
+			rlog.Println("RelayerPeer.Sign", err)
+			continue
+		}
+	}
+	if len(sigs) < int(cm.Required) {
+		return hash.Hash256{}, nil, ErrInsufficientRelayerSignature
+	}
+	return TxHash, sigs, nil
+}
+
+func (s *Relayer) signAndAdd(tx types.Transaction) (hash.Hash256, error) {
+	TxHash, sigs, err := s.collectSignatures(tx)
+	if err != nil {
+		return hash.Hash256{}, err
+	}
+	if err := s.nd.AddTx(tx, sigs); err != nil {
+		return hash.Hash256{}, err
+	}
+	return TxHash, nil
+}
@@ -0,0 +1,178 @@
+package gateway
+
+import (
+	"github.com/fletaio/fleta/common/binutil"
+	"github.com/fletaio/fleta/common/hash"
+	"github.com/fletaio/fleta/core/backend"
+	"github.com/fletaio/fleta/encoding"
+	"github.com/fletaio/fleta/process/gateway"
+)
+
+// WithdrawalJobs returns withdrawal jobs that are not completed
+// it returns no job while the platform is paused so foreign payouts are not made
+func (s *Relayer) WithdrawalJobs() ([]*WithdrawalJob, error) {
+	list := []*WithdrawalJob{}
+	if is, err := s.isPaused(); err != nil {
+		return nil, err
+	} else if is {
+		return list, nil
+	}
+	if err := s.st.View(func(txn backend.StoreReader) error {
+		return txn.Iterate(tagWithdrawal, func(key []byte, value []byte) error {
+			job := &WithdrawalJob{}
+			if err := encoding.Unmarshal(value, &job); err != nil {
+				return err
+			}
+			if !job.IsCompleted {
+				list = append(list, job)
+			}
+			return nil
+		})
+	}); err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+func (s *Relayer) cursor() (uint64, error) {
+	var Cursor uint64
+	if err := s.st.View(func(txn backend.StoreReader) error {
+		bs, err := txn.Get(tagCursor)
+		if err != nil {
+			if err == backend.ErrNotExistKey {
+				return nil
+			}
+			return err
+		}
+		Cursor = binutil.BigEndian.Uint64(bs)
+		return nil
+	}); err != nil {
+		return 0, err
+	}
+	return Cursor, nil
+}
+
+func (s *Relayer) setCursor(Cursor uint64) error {
+	return s.st.Update(func(txn backend.StoreWriter) error {
+		return txn.Set(tagCursor, binutil.BigEndian.Uint64ToBytes(Cursor))
+	})
+}
+
+func (s *Relayer) depositRecord(ERC20TXID hash.Hash256) (*depositRecord, error) {
+	rec := &depositRecord{}
+	if err := s.st.View(func(txn backend.StoreReader) error {
+		bs, err := txn.Get(toDepositKey(ERC20TXID))
+		if err != nil {
+			return err
+		}
+		return encoding.Unmarshal(bs, &rec)
+	}); err != nil {
+		return nil, err
+	}
+	return rec, nil
+}
+
+func (s *Relayer) setDepositRecord(rec *depositRecord) error {
+	bs, err := encoding.Marshal(rec)
+	if err != nil {
+		return err
+	}
+	return s.st.Update(func(txn backend.StoreWriter) error {
+		return txn.Set(toDepositKey(rec.Deposit.ERC20TXID), bs)
+	})
+}
+
+func (s *Relayer) pendingDeposits() ([]*depositRecord, error) {
+	list := []*depositRecord{}
+	if err := s.st.View(func(txn backend.StoreReader) error {
+		return txn.Iterate(tagDeposit, func(key []byte, value []byte) error {
+			rec := &depositRecord{}
+			if err := encoding.Unmarshal(value, &rec); err != nil {
+				return err
+			}
+			if !rec.IsProcessed && !rec.IsParked {
+				list = append(list, rec)
+			}
+			return nil
+		})
+	}); err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+func (s *Relayer) markDepositProcessed(tx *gateway.TokenIn) error {
+	rec, err := s.depositRecord(tx.ERC20TXID)
+	if err != nil {
+		if err != backend.ErrNotExistKey {
+			return err
+		}
+		rec = &depositRecord{
+			Deposit: &Deposit{
+				ERC20TXID: tx.ERC20TXID,
+				ERC20From: tx.ERC20From,
+			},
+		}
+		if len(tx.ToAddresses) > 0 {
+			rec.Deposit.To = tx.ToAddresses[0]
+			rec.Deposit.Amount = tx.Amounts[0]
+		}
+	}
+	rec.IsProcessed = true
+	return s.setDepositRecord(rec)
+}
+
+func (s *Relayer) withdrawalJob(CoinTXID string) (*WithdrawalJob, error) {
+	job := &WithdrawalJob{}
+	if err := s.st.View(func(txn backend.StoreReader) error {
+		bs, err := txn.Get(toWithdrawalKey(CoinTXID))
+		if err != nil {
+			if err == backend.ErrNotExistKey {
+				return ErrNotExistWithdrawalJob
+			}
+			return err
+		}
+		return encoding.Unmarshal(bs, &job)
+	}); err != nil {
+		return nil, err
+	}
+	return job, nil
+}
+
+func (s *Relayer) setWithdrawalJob(job *WithdrawalJob) error {
+	bs, err := encoding.Marshal(job)
+	if err != nil {
+		return err
+	}
+	return s.st.Update(func(txn backend.StoreWriter) error {
+		return txn.Set(toWithdrawalKey(job.CoinTXID), bs)
+	})
+}
+
+func (s *Relayer) addWithdrawalJob(job *WithdrawalJob) error {
+	if _, err := s.withdrawalJob(job.CoinTXID); err == nil {
+		return nil
+	} else if err != ErrNotExistWithdrawalJob {
+		return err
+	}
+	return s.setWithdrawalJob(job)
+}
+
+func (s *Relayer) markWithdrawalCompleted(CoinTXID string, ERC20TXID hash.Hash256) error {
+	job, err := s.withdrawalJob(CoinTXID)
+	if err != nil {
+		return err
+	}
+	job.IsCompleted = true
+	job.ERC20TXID = ERC20TXID
+	return s.setWithdrawalJob(job)
+}
+
+func (s *Relayer) isPaused() (bool, error) {
+	loader := s.cn.NewLoaderWrapper(s.gateway.ID())
+	policy, err := s.gateway.GetPolicy(loader, s.Config.Platform)
+	if err != nil {
+		return false, err
+	}
+	return policy.IsPaused, nil
+}
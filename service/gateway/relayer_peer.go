@@ -0,0 +1,84 @@
+package gateway
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	uuid "github.com/satori/go.uuid"
+
+	"github.com/fletaio/fleta/common"
+	"github.com/fletaio/fleta/core/types"
+	"github.com/fletaio/fleta/encoding"
+	"github.com/fletaio/fleta/service/apiserver"
+)
+
+// RelayerPeer asks another relayer of the committee to sign the transaction
+type RelayerPeer interface {
+	Sign(tx types.Transaction) (common.Signature, error)
+}
+
+// JRPCPeer is a RelayerPeer calling relayer.signTransaction of the api server of the other relayer
+// a request is canceled after the timeout so an unresponsive relayer does not stall the coordinator
+type JRPCPeer struct {
+	hostURL string
+	client  *http.Client
+}
+
+// NewJRPCPeer returns a JRPCPeer
+func NewJRPCPeer(HostURL string, Timeout time.Duration) *JRPCPeer {
+	if Timeout == 0 {
+		Timeout = 10 * time.Second
+	}
+	rp := &JRPCPeer{
+		hostURL: HostURL,
+		client: &http.Client{
+			Timeout: Timeout,
+		},
+	}
+	return rp
+}
+
+// Sign returns the signature of the other relayer
+func (rp *JRPCPeer) Sign(tx types.Transaction) (common.Signature, error) {
+	fc := encoding.Factory("transaction")
+	t, err := fc.TypeOf(tx)
+	if err != nil {
+		return common.Signature{}, err
+	}
+	bs, err := encoding.Marshal(tx)
+	if err != nil {
+		return common.Signature{}, err
+	}
+	req := &apiserver.JRPCRequest{
+		JSONRPC: "2.0",
+		ID:      uuid.NewV1().String(),
+		Method:  "relayer.signTransaction",
+		Params:  []interface{}{t, hex.EncodeToString(bs)},
+	}
+	body, err := json.Marshal(req)
+	if err != nil {
+		return common.Signature{}, err
+	}
+	r, err := rp.client.Post(rp.hostURL+"/api/endpoints/http", "application/json", bytes.NewReader(body))
+	if err != nil {
+		return common.Signature{}, err
+	}
+	defer r.Body.Close()
+
+	var res apiserver.JRPCResponse
+	if err := json.NewDecoder(r.Body).Decode(&res); err != nil {
+		return common.Signature{}, err
+	}
+	if res.Error != nil {
+		return common.Signature{}, fmt.Errorf("%v", res.Error)
+	}
+	str, is := res.Result.(string)
+	if !is {
+		return common.Signature{}, ErrInvalidPeerResponse
+	}
+	return common.ParseSignature(str)
+}
@@ -0,0 +1,372 @@
+package gateway
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/fletaio/fleta/common"
+	"github.com/fletaio/fleta/common/amount"
+	"github.com/fletaio/fleta/common/hash"
+	"github.com/fletaio/fleta/common/key"
+	"github.com/fletaio/fleta/core/backend"
+	_ "github.com/fletaio/fleta/core/backend/buntdb_driver"
+	"github.com/fletaio/fleta/core/chain"
+	"github.com/fletaio/fleta/core/types"
+	"github.com/fletaio/fleta/process/gateway"
+)
+
+type testProvider struct {
+	types.Provider
+	ctx    *types.Context
+	height uint32
+}
+
+func (cn *testProvider) ChainID() uint8                 { return 1 }
+func (cn *testProvider) Height() uint32                 { return cn.height }
+func (cn *testProvider) Seq(addr common.Address) uint64 { return 0 }
+func (cn *testProvider) NewLoaderWrapper(pid uint8) types.LoaderWrapper {
+	return types.NewLoaderWrapper(pid, cn.ctx)
+}
+
+type testSubmitter struct {
+	tx   types.Transaction
+	sigs []common.Signature
+}
+
+func (nd *testSubmitter) AddTx(tx types.Transaction, sigs []common.Signature) error {
+	nd.tx = tx
+	nd.sigs = sigs
+	return nil
+}
+
+type testPeer struct {
+	s *Relayer
+}
+
+func (rp *testPeer) Sign(tx types.Transaction) (common.Signature, error) {
+	return rp.s.SignTransaction(tx)
+}
+
+type testRelayerSet struct {
+	dir      string
+	gw       *gateway.Gateway
+	cm       *gateway.Committee
+	cn       *testProvider
+	relayers []*Relayer
+}
+
+func newTestRelayerSet(t *testing.T, count int, Required uint8) *testRelayerSet {
+	dir, err := ioutil.TempDir("", "relayer")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ts := &testRelayerSet{
+		dir: dir,
+		gw:  gateway.NewGateway(4),
+		cm:  &gateway.Committee{Required: Required},
+		cn:  &testProvider{ctx: types.NewEmptyContext(), height: 1},
+	}
+	reg := types.NewRegister(ts.gw.ID())
+	reg.RegisterTransaction(1, &gateway.TokenIn{})
+	reg.RegisterTransaction(3, &gateway.TokenLeave{})
+
+	ctw := types.NewContextWrapper(ts.gw.ID(), ts.cn.ctx)
+	if err := ts.gw.AddPlatform(ctw, "ETH", &gateway.Policy{}); err != nil {
+		t.Fatal(err)
+	}
+	keys := []key.Key{}
+	for i := 0; i < count; i++ {
+		k, err := key.NewMemoryKey()
+		if err != nil {
+			t.Fatal(err)
+		}
+		keys = append(keys, k)
+		ts.cm.KeyHashes = append(ts.cm.KeyHashes, common.NewPublicHash(k.PublicKey()))
+	}
+	if err := ts.gw.SetCommittee(ctw, "ETH", ts.cm); err != nil {
+		t.Fatal(err)
+	}
+	for i, k := range keys {
+		st, err := backend.Create("buntdb", filepath.Join(dir, "store"+string('a'+rune(i))))
+		if err != nil {
+			t.Fatal(err)
+		}
+		s := NewRelayer(&RelayerConfig{
+			Platform:       "ETH",
+			GatewayAddress: common.NewAddress(0, 1, 0),
+			Key:            k,
+			IsCoordinator:  i == 0,
+		}, st, NewFileWatcher(filepath.Join(dir, "deposits"+string('a'+rune(i))), filepath.Join(dir, "payouts"+string('a'+rune(i)))))
+		s.gateway = ts.gw
+		s.cn = ts.cn
+		ts.relayers = append(ts.relayers, s)
+	}
+	for i, s := range ts.relayers {
+		for j, v := range ts.relayers {
+			if i != j {
+				s.Config.Peers = append(s.Config.Peers, &testPeer{s: v})
+			}
+		}
+	}
+	return ts
+}
+
+func (ts *testRelayerSet) Close() {
+	os.RemoveAll(ts.dir)
+}
+
+func (ts *testRelayerSet) writeDeposits(t *testing.T, index int, deposits ...*Deposit) {
+	data := []byte{}
+	for _, dp := range deposits {
+		bs, err := dp.MarshalJSON()
+		if err != nil {
+			t.Fatal(err)
+		}
+		data = append(data, bs...)
+		data = append(data, '\n')
+	}
+	if err := ioutil.WriteFile(filepath.Join(ts.dir, "deposits"+string('a'+rune(index))), data, 0600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func (ts *testRelayerSet) writePayouts(t *testing.T, index int, payouts ...*Payout) {
+	data := []byte{}
+	for _, po := range payouts {
+		bs, err := po.MarshalJSON()
+		if err != nil {
+			t.Fatal(err)
+		}
+		data = append(data, bs...)
+		data = append(data, '\n')
+	}
+	if err := ioutil.WriteFile(filepath.Join(ts.dir, "payouts"+string('a'+rune(index))), data, 0600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func testDeposit(Amount int64) *Deposit {
+	return &Deposit{
+		ERC20TXID: hash.Hash([]byte("erc20 tx")),
+		To:        common.NewAddress(0, 2, 0),
+		Amount:    amount.NewCoinAmount(uint64(Amount), 0),
+	}
+}
+
+func TestRelayerCollectsCommitteeSignatures(t *testing.T) {
+	ts := newTestRelayerSet(t, 3, 2)
+	defer ts.Close()
+
+	dp := testDeposit(10)
+	ts.writeDeposits(t, 0, dp)
+	ts.writeDeposits(t, 2, dp)
+
+	nd := &testSubmitter{}
+	ts.relayers[0].SetNode(nd)
+	for _, s := range ts.relayers[1:] {
+		if err := s.relayDeposits(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := ts.relayers[0].relayDeposits(); err != nil {
+		t.Fatal(err)
+	}
+
+	tx, is := nd.tx.(*gateway.TokenIn)
+	if !is {
+		t.Fatal("TokenIn should be submitted")
+	}
+	if tx.ERC20TXID != dp.ERC20TXID || tx.ToAddresses[0] != dp.To || !tx.Amounts[0].Equal(dp.Amount) {
+		t.Fatal("TokenIn should match the deposit")
+	}
+	if len(nd.sigs) != int(ts.cm.Required) {
+		t.Fatal("the threshold of signatures should be collected", len(nd.sigs))
+	}
+	TxHash := chain.HashTransaction(ts.cn.ChainID(), tx)
+	signers := []common.PublicHash{}
+	for _, sig := range nd.sigs {
+		pubkey, err := common.RecoverPubkey(TxHash, sig)
+		if err != nil {
+			t.Fatal(err)
+		}
+		signers = append(signers, common.NewPublicHash(pubkey))
+	}
+	if err := ts.cm.Validate(signers); err != nil {
+		t.Fatal(err)
+	}
+	if signers[0] != ts.cm.KeyHashes[0] || signers[1] != ts.cm.KeyHashes[2] {
+		t.Fatal("the coordinator and the relayer which confirmed the deposit should sign")
+	}
+}
+
+func TestRelayerRejectsMismatchedDeposit(t *testing.T) {
+	ts := newTestRelayerSet(t, 3, 2)
+	defer ts.Close()
+
+	ts.writeDeposits(t, 0, testDeposit(10))
+	ts.writeDeposits(t, 1, testDeposit(20))
+
+	nd := &testSubmitter{}
+	ts.relayers[0].SetNode(nd)
+	for _, s := range ts.relayers[1:] {
+		if err := s.relayDeposits(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := ts.relayers[0].relayDeposits(); err != nil {
+		t.Fatal(err)
+	}
+	if nd.tx != nil {
+		t.Fatal("TokenIn should not be submitted")
+	}
+	if Cursor, err := ts.relayers[0].cursor(); err != nil {
+		t.Fatal(err)
+	} else if Cursor != 1 {
+		t.Fatal("the failed deposit should not block the cursor", Cursor)
+	}
+
+	ts.writeDeposits(t, 2, testDeposit(10))
+	if err := ts.relayers[2].relayDeposits(); err != nil {
+		t.Fatal(err)
+	}
+	if err := ts.relayers[0].resubmitDeposits(); err != nil {
+		t.Fatal(err)
+	}
+	if nd.tx != nil {
+		t.Fatal("the failed deposit should wait resubmit blocks")
+	}
+	ts.cn.height += ts.relayers[0].Config.ResubmitBlocks
+	if err := ts.relayers[0].resubmitDeposits(); err != nil {
+		t.Fatal(err)
+	}
+	if nd.tx == nil {
+		t.Fatal("TokenIn should be submitted after another relayer confirms the deposit")
+	}
+}
+
+func TestRelayerParksFailedDeposit(t *testing.T) {
+	ts := newTestRelayerSet(t, 3, 2)
+	defer ts.Close()
+
+	dp := testDeposit(10)
+	ts.writeDeposits(t, 0, dp)
+
+	nd := &testSubmitter{}
+	ts.relayers[0].SetNode(nd)
+	ts.relayers[0].Config.MaxSubmitAttempts = 2
+	if err := ts.relayers[0].relayDeposits(); err != nil {
+		t.Fatal(err)
+	}
+	ts.cn.height += ts.relayers[0].Config.ResubmitBlocks
+	if err := ts.relayers[0].resubmitDeposits(); err != nil {
+		t.Fatal(err)
+	}
+	rec, err := ts.relayers[0].depositRecord(dp.ERC20TXID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !rec.IsParked || rec.Attempts != 2 {
+		t.Fatal("the deposit should be parked after max attempts", rec.Attempts)
+	}
+	if list, err := ts.relayers[0].pendingDeposits(); err != nil {
+		t.Fatal(err)
+	} else if len(list) != 0 {
+		t.Fatal("the parked deposit should not be resubmitted")
+	}
+}
+
+func TestRelayerVerifiesPayout(t *testing.T) {
+	ts := newTestRelayerSet(t, 3, 2)
+	defer ts.Close()
+
+	job := &WithdrawalJob{
+		CoinTXID: types.TransactionID(1, 0),
+		CoinFrom: common.NewAddress(0, 2, 0),
+		Amount:   amount.NewCoinAmount(10, 0),
+	}
+	for _, s := range ts.relayers {
+		if err := s.addWithdrawalJob(job); err != nil {
+			t.Fatal(err)
+		}
+	}
+	po := &Payout{
+		ERC20TXID: hash.Hash([]byte("payout tx")),
+		ERC20To:   job.ERC20To,
+		Amount:    job.Amount,
+	}
+
+	nd := &testSubmitter{}
+	ts.relayers[0].SetNode(nd)
+	if _, err := ts.relayers[0].CompleteWithdrawal(job.CoinTXID, po.ERC20TXID); err != ErrNotConfirmedPayout {
+		t.Fatal("the payout should be confirmed by the coordinator", err)
+	}
+
+	ts.writePayouts(t, 0, po)
+	ts.writePayouts(t, 1, &Payout{
+		ERC20TXID: po.ERC20TXID,
+		ERC20To:   po.ERC20To,
+		Amount:    amount.NewCoinAmount(1, 0),
+	})
+	if _, err := ts.relayers[0].CompleteWithdrawal(job.CoinTXID, po.ERC20TXID); err != ErrInsufficientRelayerSignature {
+		t.Fatal("peers should not sign the mismatched payout", err)
+	}
+
+	ts.writePayouts(t, 2, po)
+	if _, err := ts.relayers[0].CompleteWithdrawal(job.CoinTXID, po.ERC20TXID); err != nil {
+		t.Fatal(err)
+	}
+	if tx, is := nd.tx.(*gateway.TokenLeave); !is || tx.ERC20TXID != po.ERC20TXID {
+		t.Fatal("TokenLeave should be submitted")
+	}
+}
+
+func TestRelayerSkipsPausedWithdrawal(t *testing.T) {
+	ts := newTestRelayerSet(t, 3, 2)
+	defer ts.Close()
+
+	job := &WithdrawalJob{
+		CoinTXID: types.TransactionID(1, 0),
+		CoinFrom: common.NewAddress(0, 2, 0),
+		Amount:   amount.NewCoinAmount(10, 0),
+	}
+	po := &Payout{
+		ERC20TXID: hash.Hash([]byte("payout tx")),
+		ERC20To:   job.ERC20To,
+		Amount:    job.Amount,
+	}
+	for i, s := range ts.relayers {
+		if err := s.addWithdrawalJob(job); err != nil {
+			t.Fatal(err)
+		}
+		ts.writePayouts(t, i, po)
+	}
+
+	ctw := types.NewContextWrapper(ts.gw.ID(), ts.cn.ctx)
+	if err := ts.gw.SetPolicy(ctw, "ETH", &gateway.Policy{IsPaused: true}); err != nil {
+		t.Fatal(err)
+	}
+	if jobs, err := ts.relayers[0].WithdrawalJobs(); err != nil {
+		t.Fatal(err)
+	} else if len(jobs) != 0 {
+		t.Fatal("withdrawal jobs should be skipped while the platform is paused")
+	}
+	nd := &testSubmitter{}
+	ts.relayers[0].SetNode(nd)
+	if _, err := ts.relayers[0].CompleteWithdrawal(job.CoinTXID, po.ERC20TXID); err != gateway.ErrPlatformPaused {
+		t.Fatal("the withdrawal should not be completed while the platform is paused", err)
+	}
+
+	if err := ts.gw.SetPolicy(ctw, "ETH", &gateway.Policy{}); err != nil {
+		t.Fatal(err)
+	}
+	if jobs, err := ts.relayers[0].WithdrawalJobs(); err != nil {
+		t.Fatal(err)
+	} else if len(jobs) != 1 {
+		t.Fatal("withdrawal jobs should be returned after the platform is resumed")
+	}
+	if _, err := ts.relayers[0].CompleteWithdrawal(job.CoinTXID, po.ERC20TXID); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,158 @@
+package gateway
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/fletaio/fleta/common"
+	"github.com/fletaio/fleta/common/amount"
+	"github.com/fletaio/fleta/common/hash"
+	"github.com/fletaio/fleta/process/gateway"
+)
+
+// Deposit is the confirmed deposit to the gateway on the foreign chain
+type Deposit struct {
+	ERC20TXID hash.Hash256
+	ERC20From gateway.ERC20Address
+	To        common.Address
+	Amount    *amount.Amount
+}
+
+// MarshalJSON is a marshaler function
+func (dp *Deposit) MarshalJSON() ([]byte, error) {
+	var buffer bytes.Buffer
+	buffer.WriteString(`{`)
+	buffer.WriteString(`"erc20_txid":`)
+	if bs, err := dp.ERC20TXID.MarshalJSON(); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"erc20_from":`)
+	if bs, err := dp.ERC20From.MarshalJSON(); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"to":`)
+	if bs, err := dp.To.MarshalJSON(); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"amount":`)
+	if bs, err := dp.Amount.MarshalJSON(); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`}`)
+	return buffer.Bytes(), nil
+}
+
+// Payout is the confirmed payout of the gateway on the foreign chain
+type Payout struct {
+	ERC20TXID hash.Hash256
+	ERC20To   gateway.ERC20Address
+	Amount    *amount.Amount
+}
+
+// MarshalJSON is a marshaler function
+func (po *Payout) MarshalJSON() ([]byte, error) {
+	var buffer bytes.Buffer
+	buffer.WriteString(`{`)
+	buffer.WriteString(`"erc20_txid":`)
+	if bs, err := po.ERC20TXID.MarshalJSON(); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"erc20_to":`)
+	if bs, err := po.ERC20To.MarshalJSON(); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"amount":`)
+	if bs, err := po.Amount.MarshalJSON(); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`}`)
+	return buffer.Bytes(), nil
+}
+
+// WithdrawalJob is the payout to the foreign chain requested by the TokenOut transaction
+// it is completed by the TokenLeave transaction carrying the foreign payout transaction id
+type WithdrawalJob struct {
+	CoinTXID    string
+	CoinFrom    common.Address
+	ERC20To     gateway.ERC20Address
+	Amount      *amount.Amount
+	ERC20TXID   hash.Hash256
+	IsCompleted bool
+}
+
+// MarshalJSON is a marshaler function
+func (job *WithdrawalJob) MarshalJSON() ([]byte, error) {
+	var buffer bytes.Buffer
+	buffer.WriteString(`{`)
+	buffer.WriteString(`"coin_txid":`)
+	if bs, err := json.Marshal(job.CoinTXID); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"coin_from":`)
+	if bs, err := job.CoinFrom.MarshalJSON(); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"erc20_to":`)
+	if bs, err := job.ERC20To.MarshalJSON(); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"amount":`)
+	if bs, err := job.Amount.MarshalJSON(); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"erc20_txid":`)
+	if bs, err := job.ERC20TXID.MarshalJSON(); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"is_completed":`)
+	if bs, err := json.Marshal(job.IsCompleted); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`}`)
+	return buffer.Bytes(), nil
+}
+
+type depositRecord struct {
+	Deposit      *Deposit
+	TxHash       hash.Hash256
+	SubmitHeight uint32
+	IsProcessed  bool
+	Attempts     uint32 `msgpack:",omitempty"`
+	IsParked     bool   `msgpack:",omitempty"`
+}
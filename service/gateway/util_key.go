@@ -0,0 +1,26 @@
+package gateway
+
+import (
+	"github.com/fletaio/fleta/common/hash"
+)
+
+// tags
+var (
+	tagCursor     = []byte{1, 0}
+	tagDeposit    = []byte{2, 0}
+	tagWithdrawal = []byte{3, 0}
+)
+
+func toDepositKey(ERC20TXID hash.Hash256) []byte {
+	bs := make([]byte, 2+hash.Hash256Size)
+	copy(bs, tagDeposit)
+	copy(bs[2:], ERC20TXID[:])
+	return bs
+}
+
+func toWithdrawalKey(CoinTXID string) []byte {
+	bs := make([]byte, 2+len(CoinTXID))
+	copy(bs, tagWithdrawal)
+	copy(bs[2:], []byte(CoinTXID))
+	return bs
+}
@@ -0,0 +1,144 @@
+package gateway
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"strings"
+
+	"github.com/fletaio/fleta/common/hash"
+)
+
+// ForeignChainWatcher delivers deposits and payouts confirmed on the foreign chain
+// the cursor is opaque to the relayer and is persisted to resume after restarts
+type ForeignChainWatcher interface {
+	ConfirmedDeposits(Cursor uint64) ([]*Deposit, uint64, error)
+	ConfirmedPayout(ERC20TXID hash.Hash256) (*Payout, error)
+}
+
+// FileWatcher is a ForeignChainWatcher replaying deposits and payouts from files which have one json item per line
+// the cursor is the number of deposit lines already delivered
+type FileWatcher struct {
+	path       string
+	payoutPath string
+}
+
+// NewFileWatcher returns a FileWatcher
+func NewFileWatcher(DepositPath string, PayoutPath string) *FileWatcher {
+	fw := &FileWatcher{
+		path:       DepositPath,
+		payoutPath: PayoutPath,
+	}
+	return fw
+}
+
+// ConfirmedPayout returns the payout of the foreign transaction id in the payout file
+func (fw *FileWatcher) ConfirmedPayout(ERC20TXID hash.Hash256) (*Payout, error) {
+	f, err := os.Open(fw.payoutPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, ErrNotConfirmedPayout
+		}
+		return nil, err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		text := strings.TrimSpace(scanner.Text())
+		if len(text) == 0 {
+			continue
+		}
+		var v struct {
+			ERC20TXID string          `json:"erc20_txid"`
+			ERC20To   json.RawMessage `json:"erc20_to"`
+			Amount    json.RawMessage `json:"amount"`
+		}
+		if err := json.Unmarshal([]byte(text), &v); err != nil {
+			return nil, err
+		}
+		if len(v.ERC20TXID) == 0 || v.ERC20To == nil || v.Amount == nil {
+			return nil, ErrInvalidPayoutFormat
+		}
+		TXID, err := hash.ParseHash(strings.TrimPrefix(v.ERC20TXID, "0x"))
+		if err != nil {
+			return nil, err
+		}
+		if TXID != ERC20TXID {
+			continue
+		}
+		po := &Payout{
+			ERC20TXID: TXID,
+		}
+		if err := po.ERC20To.UnmarshalJSON(v.ERC20To); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(v.Amount, &po.Amount); err != nil {
+			return nil, err
+		}
+		return po, nil
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return nil, ErrNotConfirmedPayout
+}
+
+// ConfirmedDeposits returns deposits appended to the file after the cursor
+func (fw *FileWatcher) ConfirmedDeposits(Cursor uint64) ([]*Deposit, uint64, error) {
+	f, err := os.Open(fw.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, Cursor, nil
+		}
+		return nil, Cursor, err
+	}
+	defer f.Close()
+
+	list := []*Deposit{}
+	line := uint64(0)
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		text := strings.TrimSpace(scanner.Text())
+		if len(text) == 0 {
+			continue
+		}
+		line++
+		if line <= Cursor {
+			continue
+		}
+		var v struct {
+			ERC20TXID string          `json:"erc20_txid"`
+			ERC20From json.RawMessage `json:"erc20_from"`
+			To        json.RawMessage `json:"to"`
+			Amount    json.RawMessage `json:"amount"`
+		}
+		if err := json.Unmarshal([]byte(text), &v); err != nil {
+			return nil, Cursor, err
+		}
+		if len(v.ERC20TXID) == 0 || v.ERC20From == nil || v.To == nil || v.Amount == nil {
+			return nil, Cursor, ErrInvalidDepositFormat
+		}
+		ERC20TXID, err := hash.ParseHash(strings.TrimPrefix(v.ERC20TXID, "0x"))
+		if err != nil {
+			return nil, Cursor, err
+		}
+		dp := &Deposit{
+			ERC20TXID: ERC20TXID,
+		}
+		if err := dp.ERC20From.UnmarshalJSON(v.ERC20From); err != nil {
+			return nil, Cursor, err
+		}
+		if err := dp.To.UnmarshalJSON(v.To); err != nil {
+			return nil, Cursor, err
+		}
+		if err := json.Unmarshal(v.Amount, &dp.Amount); err != nil {
+			return nil, Cursor, err
+		}
+		list = append(list, dp)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, Cursor, err
+	}
+	return list, Cursor + uint64(len(list)), nil
+}